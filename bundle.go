@@ -0,0 +1,210 @@
+package main
+
+import (
+	"fmt"
+	"path"
+	"strings"
+
+	"github.com/RedVentures/protoc-gen-jsonschema/internal/jsonschema"
+	"github.com/golang/protobuf/protoc-gen-go/descriptor"
+	plugin "github.com/golang/protobuf/protoc-gen-go/plugin"
+)
+
+// bundleMode is whether schemas are bundled into one document per proto file or package (set with the bundle parameter).
+type bundleMode int
+
+const (
+	bundleNone    bundleMode = iota // Every message and enum gets a file of its own (the default)
+	bundleFile                      // One document for each proto file
+	bundlePackage                   // One document for each proto package
+)
+
+var bundleModeNames = map[bundleMode]string{
+	bundleNone:    "none",
+	bundleFile:    "file",
+	bundlePackage: "package",
+}
+
+func (m bundleMode) String() string {
+	return bundleModeNames[m]
+}
+
+// Set parses a bundle mode (this makes it a flag.Value).
+func (m *bundleMode) Set(name string) error {
+	for mode, modeName := range bundleModeNames {
+		if modeName == name {
+			*m = mode
+			return nil
+		}
+	}
+	return fmt.Errorf("unsupported bundle %q (expected none, file or package)", name)
+}
+
+// The name bundles of proto files without a package are given (with bundle=package):
+const defaultPackageBundleName = "default"
+
+// A schemaBundle is a single JSON-Schema document holding every message and enum of some proto files in its
+// definitions, with an index (an "anyOf" of references to them) at the top.
+type schemaBundle struct {
+	name  string                          // What the bundle is named after (the proto file or the package)
+	file  *descriptor.FileDescriptorProto // The first file in the bundle (which decides where it is written)
+	state *conversionState
+	index []string // Names of the definitions in the index (in the order they were added)
+}
+
+// Returns the name of the bundle a proto file belongs in:
+func bundleName(file *descriptor.FileDescriptorProto) string {
+	if bundling == bundlePackage {
+		if file.GetPackage() == "" {
+			return defaultPackageBundleName
+		}
+		return file.GetPackage()
+	}
+	return strings.TrimSuffix(path.Base(file.GetName()), path.Ext(file.GetName()))
+}
+
+func newSchemaBundle(name string, file *descriptor.FileDescriptorProto) *schemaBundle {
+	// There is no root message, so everything (even messages referring to themselves) is referenced from the definitions:
+	return &schemaBundle{
+		name: name,
+		file: file,
+		state: &conversionState{
+			converting:     make(map[string]bool),
+			definitions:    make(jsonschema.Definitions),
+			useDefinitions: true,
+		},
+	}
+}
+
+// Adds the enums and messages of a proto file to a bundle (nested ones only end up in the index with generate_nested_types):
+func (b *schemaBundle) addFile(file *descriptor.FileDescriptorProto) error {
+	for _, enum := range file.GetEnumType() {
+		if err := b.addEnum(qualifiedName(file.GetPackage(), enum.GetName()), enum); err != nil {
+			return err
+		}
+	}
+
+	if len(file.GetMessageType()) == 0 {
+		return nil
+	}
+	pkg, ok := globalPkg.relativelyLookupPackage(file.GetPackage())
+	if !ok {
+		return fmt.Errorf("no such package found: %s", file.GetPackage())
+	}
+	for _, msg := range file.GetMessageType() {
+		if err := b.addMessage(pkg, qualifiedName(file.GetPackage(), msg.GetName()), msg); err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+// Adds an enum to the definitions and index of a bundle:
+func (b *schemaBundle) addEnum(definitionName string, enum *descriptor.EnumDescriptorProto) error {
+	logWithLevel(LOG_INFO, "Bundling JSON-schema for ENUM (%v) into [%v]", definitionName, b.name)
+	if _, ok := b.state.definitions[definitionName]; !ok {
+		enumJSONSchema, err := convertEnumType(enum)
+		if err != nil {
+			return err
+		}
+		enumJSONSchema.Version = ""
+		b.state.definitions[definitionName] = &enumJSONSchema
+	}
+	b.index = append(b.index, definitionName)
+	return nil
+}
+
+// Adds a message to the definitions and index of a bundle (along with its nested types, with generate_nested_types):
+func (b *schemaBundle) addMessage(pkg *ProtoPackage, definitionName string, msg *descriptor.DescriptorProto) error {
+	logWithLevel(LOG_INFO, "Bundling JSON-schema for MESSAGE (%v) into [%v]", definitionName, b.name)
+
+	// The message may already be there (if something converted earlier refers to it):
+	if _, ok := b.state.definitions[definitionName]; !ok {
+		b.state.converting["."+definitionName] = true
+		messageJSONSchema, err := convertMessageType(pkg, msg, b.state)
+		delete(b.state.converting, "."+definitionName)
+		if err != nil {
+			return err
+		}
+		messageJSONSchema.Version = ""
+		b.state.definitions[definitionName] = &messageJSONSchema
+	}
+	b.index = append(b.index, definitionName)
+
+	if !generateNestedTypes {
+		return nil
+	}
+	for _, nestedMsg := range msg.GetNestedType() {
+		if nestedMsg.GetOptions().GetMapEntry() {
+			continue
+		}
+		if err := b.addMessage(pkg, definitionName+"."+nestedMsg.GetName(), nestedMsg); err != nil {
+			return err
+		}
+	}
+	for _, enum := range msg.GetEnumType() {
+		if err := b.addEnum(definitionName+"."+enum.GetName(), enum); err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+// Writes a bundle out as a file for the response:
+func (b *schemaBundle) responseFile() (*plugin.CodeGeneratorResponse_File, error) {
+	bundleJSONSchema := jsonschema.Type{
+		Version:     schemaDraft.Version(),
+		Definitions: b.state.definitions,
+	}
+	for _, definitionName := range b.index {
+		bundleJSONSchema.AnyOf = append(bundleJSONSchema.AnyOf, &jsonschema.Type{Ref: definitionRef(definitionName)})
+	}
+	return newResponseFile(outputFileName(b.file, b.name), &bundleJSONSchema)
+}
+
+// Converts proto files into bundles (in the order the files were given, with each bundle written where its first file is).
+// The proto files the bundles were named after are returned too:
+func convertBundles(files []*descriptor.FileDescriptorProto) ([]*plugin.CodeGeneratorResponse_File, []string, error) {
+	var bundles []*schemaBundle
+	bundlesByName := make(map[string]*schemaBundle) // By proto file name or package
+	for _, file := range files {
+		// Proto files with the same name in different directories are still bundled separately:
+		key := file.GetName()
+		if bundling == bundlePackage {
+			key = file.GetPackage()
+		}
+		b, ok := bundlesByName[key]
+		if !ok {
+			b = newSchemaBundle(bundleName(file), file)
+			bundles = append(bundles, b)
+			bundlesByName[key] = b
+		}
+		if err := b.addFile(file); err != nil {
+			return nil, nil, fmt.Errorf("failed to bundle %s: %v", file.GetName(), err)
+		}
+	}
+
+	// Bundles without any messages or enums are left out (like files without any are):
+	response := []*plugin.CodeGeneratorResponse_File{}
+	var sources []string
+	for _, b := range bundles {
+		if len(b.index) == 0 {
+			continue
+		}
+		resFile, err := b.responseFile()
+		if err != nil {
+			return nil, nil, err
+		}
+		response = append(response, resFile)
+		sources = append(sources, b.file.GetName())
+	}
+	return response, sources, nil
+}
+
+// Returns the fully qualified name of a message or enum (without a leading dot, like the names of definitions):
+func qualifiedName(pkgName, name string) string {
+	if pkgName == "" {
+		return name
+	}
+	return pkgName + "." + name
+}
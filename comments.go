@@ -0,0 +1,137 @@
+package main
+
+import (
+	"fmt"
+	"strings"
+
+	"github.com/RedVentures/protoc-gen-jsonschema/internal/jsonschema"
+	"github.com/golang/protobuf/proto"
+	"github.com/golang/protobuf/protoc-gen-go/descriptor"
+)
+
+// Field numbers used in the paths of SourceCodeInfo locations (see descriptor.proto):
+const (
+	fileMessageTypePath   = 4 // FileDescriptorProto.message_type
+	fileEnumTypePath      = 5 // FileDescriptorProto.enum_type
+	messageFieldPath      = 2 // DescriptorProto.field
+	messageNestedTypePath = 3 // DescriptorProto.nested_type
+	messageEnumTypePath   = 4 // DescriptorProto.enum_type
+	enumValuePath         = 2 // EnumDescriptorProto.value
+)
+
+// Comments for messages, fields, enums and enum values (keyed by their descriptors):
+var sourceComments = make(map[proto.Message]string)
+
+// stringList is a flag which can be given more than once.
+type stringList []string
+
+func (l *stringList) String() string {
+	return strings.Join(*l, ",")
+}
+
+func (l *stringList) Set(value string) error {
+	*l = append(*l, value)
+	return nil
+}
+
+// Remembers the comments for everything defined in a proto file (protoc only provides these for files to generate):
+func registerComments(file *descriptor.FileDescriptorProto) {
+	locations := make(map[string]*descriptor.SourceCodeInfo_Location)
+	for _, location := range file.GetSourceCodeInfo().GetLocation() {
+		locations[fmt.Sprint(location.GetPath())] = location
+	}
+
+	for i, msg := range file.GetMessageType() {
+		registerMessageComments(locations, msg, []int32{fileMessageTypePath, int32(i)})
+	}
+	for i, enum := range file.GetEnumType() {
+		registerEnumComments(locations, enum, []int32{fileEnumTypePath, int32(i)})
+	}
+}
+
+func registerMessageComments(locations map[string]*descriptor.SourceCodeInfo_Location, msg *descriptor.DescriptorProto, path []int32) {
+	registerComment(locations, msg, path)
+	for i, fieldDesc := range msg.GetField() {
+		registerComment(locations, fieldDesc, appendPath(path, messageFieldPath, i))
+	}
+	for i, nestedMsg := range msg.GetNestedType() {
+		registerMessageComments(locations, nestedMsg, appendPath(path, messageNestedTypePath, i))
+	}
+	for i, enum := range msg.GetEnumType() {
+		registerEnumComments(locations, enum, appendPath(path, messageEnumTypePath, i))
+	}
+}
+
+func registerEnumComments(locations map[string]*descriptor.SourceCodeInfo_Location, enum *descriptor.EnumDescriptorProto, path []int32) {
+	registerComment(locations, enum, path)
+	for i, enumValue := range enum.GetValue() {
+		registerComment(locations, enumValue, appendPath(path, enumValuePath, i))
+	}
+}
+
+func registerComment(locations map[string]*descriptor.SourceCodeInfo_Location, desc proto.Message, path []int32) {
+	location, ok := locations[fmt.Sprint(path)]
+	if !ok {
+		return
+	}
+	if comment := formatComment(location.GetLeadingComments(), location.GetTrailingComments()); comment != "" {
+		sourceComments[desc] = comment
+	}
+}
+
+// Returns a copy of path (so siblings don't share a backing array) with a component and index appended:
+func appendPath(path []int32, component int32, index int) []int32 {
+	return append(append([]int32{}, path...), component, int32(index))
+}
+
+// Tidies up leading and trailing comments, leaving out any lines with an excluded prefix (eg "@exclude"):
+func formatComment(comments ...string) string {
+	var paragraphs []string
+	for _, comment := range comments {
+		var lines []string
+	lineLoop:
+		for _, line := range strings.Split(comment, "\n") {
+			line = strings.TrimSpace(line)
+			for _, prefix := range excludeCommentPrefixes {
+				if strings.HasPrefix(line, prefix) {
+					continue lineLoop
+				}
+			}
+			lines = append(lines, line)
+		}
+		if paragraph := strings.TrimSpace(strings.Join(lines, "\n")); paragraph != "" {
+			paragraphs = append(paragraphs, paragraph)
+		}
+	}
+	return strings.Join(paragraphs, "\n\n")
+}
+
+// Uses the comments for a descriptor as the title (first line) and description (everything) of a JSON-Schema:
+func applyComments(jsonSchemaType *jsonschema.Type, desc proto.Message) {
+	comment, ok := sourceComments[desc]
+	if !ok {
+		return
+	}
+	jsonSchemaType.Title = strings.SplitN(comment, "\n", 2)[0]
+	jsonSchemaType.Description = comment
+}
+
+// Enum values can't be described individually, so their comments are listed in the description of the enum:
+func applyEnumComments(jsonSchemaType *jsonschema.Type, enum *descriptor.EnumDescriptorProto) {
+	applyComments(jsonSchemaType, enum)
+
+	var valueComments []string
+	for _, enumValue := range enum.GetValue() {
+		if comment, ok := sourceComments[enumValue]; ok {
+			valueComments = append(valueComments, fmt.Sprintf("%s: %s", enumValue.GetName(), comment))
+		}
+	}
+	if len(valueComments) == 0 {
+		return
+	}
+
+	if jsonSchemaType.Description != "" {
+		jsonSchemaType.Description += "\n\n"
+	}
+	jsonSchemaType.Description += strings.Join(valueComments, "\n")
+}
@@ -0,0 +1,167 @@
+package main
+
+import (
+	"fmt"
+	"path"
+	"regexp"
+	"strings"
+	"unicode"
+
+	"github.com/golang/protobuf/protoc-gen-go/descriptor"
+)
+
+// The template output files are named with when none is given:
+const defaultFileNameTemplate = "{message}.{extension}"
+
+// Matches the last part of a package when it's a version (eg "v1" or "v1beta1"):
+var packageVersionRegexp = regexp.MustCompile(`^v[0-9]+((alpha|beta)[0-9]*)?$`)
+
+// What each placeholder is replaced with (typeName is the path of the message or enum within the file, eg "Outer.Inner"):
+var fileNamePlaceholders = map[string]func(file *descriptor.FileDescriptorProto, typeName string) string{
+	"package": func(file *descriptor.FileDescriptorProto, typeName string) string {
+		return file.GetPackage()
+	},
+	"file": func(file *descriptor.FileDescriptorProto, typeName string) string {
+		return strings.TrimSuffix(path.Base(file.GetName()), path.Ext(file.GetName()))
+	},
+	"message": func(file *descriptor.FileDescriptorProto, typeName string) string {
+		return typeName
+	},
+	"extension": func(file *descriptor.FileDescriptorProto, typeName string) string {
+		return outputFormatExtensions[schemaFormat]
+	},
+	"version": func(file *descriptor.FileDescriptorProto, typeName string) string {
+		packageParts := strings.Split(file.GetPackage(), ".")
+		if version := packageParts[len(packageParts)-1]; packageVersionRegexp.MatchString(version) {
+			return version
+		}
+		return ""
+	},
+}
+
+// How each modifier changes the value of a placeholder:
+var fileNameModifiers = map[string]func(string) string{
+	"lower": strings.ToLower,
+	"upper": strings.ToUpper,
+	"kebab": func(value string) string { return joinWords(value, "-") },
+	"snake": func(value string) string { return joinWords(value, "_") },
+}
+
+// fileNameTemplate is how output files are named (set with the filename parameter). Placeholders are written in braces
+// (eg "{message}"), and can be followed by modifiers (eg "{message|kebab}"):
+type fileNameTemplate struct {
+	template string
+	parts    []fileNameTemplatePart
+}
+
+// A fileNameTemplatePart is either literal text, or a placeholder (with its modifiers):
+type fileNameTemplatePart struct {
+	literal     string
+	placeholder string
+	modifiers   []string
+}
+
+func (t fileNameTemplate) String() string {
+	if t.template == "" {
+		return defaultFileNameTemplate
+	}
+	return t.template
+}
+
+// Set parses a filename template (this makes it a flag.Value).
+func (t *fileNameTemplate) Set(template string) error {
+	parsed := fileNameTemplate{template: template}
+	for remaining := template; remaining != ""; {
+		start := strings.IndexByte(remaining, '{')
+		if start < 0 {
+			parsed.parts = append(parsed.parts, fileNameTemplatePart{literal: remaining})
+			break
+		}
+		if start > 0 {
+			parsed.parts = append(parsed.parts, fileNameTemplatePart{literal: remaining[:start]})
+		}
+		end := strings.IndexByte(remaining[start:], '}')
+		if end < 0 {
+			return fmt.Errorf("unclosed placeholder in filename template %q", template)
+		}
+		names := strings.Split(remaining[start+1:start+end], "|")
+		if _, ok := fileNamePlaceholders[names[0]]; !ok {
+			return fmt.Errorf("unknown placeholder %q in filename template %q (expected package, file, message, version or extension)", names[0], template)
+		}
+		for _, modifier := range names[1:] {
+			if _, ok := fileNameModifiers[modifier]; !ok {
+				return fmt.Errorf("unknown modifier %q in filename template %q (expected lower, upper, kebab or snake)", modifier, template)
+			}
+		}
+		parsed.parts = append(parsed.parts, fileNameTemplatePart{placeholder: names[0], modifiers: names[1:]})
+		remaining = remaining[start+end+1:]
+	}
+	if len(parsed.parts) == 0 {
+		return fmt.Errorf("empty filename template")
+	}
+	*t = parsed
+	return nil
+}
+
+// Separators which are left out next to an empty placeholder (so "{message}-{version}" doesn't end in a "-"):
+const fileNameSeparators = "-_."
+
+// Names the output file for a message or enum. Empty placeholders (eg {version} for a package without a version) take
+// a separator next to them with them, and empty directories are left out:
+func (t fileNameTemplate) execute(file *descriptor.FileDescriptorProto, typeName string) string {
+	if t.template == "" {
+		return fmt.Sprintf("%s.%s", typeName, outputFormatExtensions[schemaFormat])
+	}
+	var fileName string
+	dropSeparator := false // Whether the next separator goes (when there wasn't one before an empty placeholder)
+	for _, part := range t.parts {
+		value := part.literal
+		if part.placeholder != "" {
+			value = fileNamePlaceholders[part.placeholder](file, typeName)
+			for _, modifier := range part.modifiers {
+				value = fileNameModifiers[modifier](value)
+			}
+		}
+		if value == "" {
+			if fileName != "" && strings.ContainsAny(fileName[len(fileName)-1:], fileNameSeparators) {
+				fileName = fileName[:len(fileName)-1]
+			} else {
+				dropSeparator = true
+			}
+			continue
+		}
+		if dropSeparator && part.placeholder == "" && strings.ContainsAny(value[:1], fileNameSeparators) {
+			value = value[1:]
+		}
+		fileName, dropSeparator = fileName+value, false
+	}
+	var pathParts []string
+	for _, pathPart := range strings.Split(fileName, "/") {
+		if pathPart != "" {
+			pathParts = append(pathParts, pathPart)
+		}
+	}
+	return strings.Join(pathParts, "/")
+}
+
+// Lowercases a value and joins its words with a separator (words are split at case changes, and on "_" and "-", so
+// "HTTPRequest_v2" becomes "http-request-v2" with "-"):
+func joinWords(value, separator string) string {
+	runes := []rune(value)
+	var joined strings.Builder
+	for i, r := range runes {
+		switch {
+		case r == '_' || r == '-':
+			joined.WriteString(separator)
+			continue
+		case i > 0 && unicode.IsUpper(r):
+			previous := runes[i-1]
+			nextIsLower := i+1 < len(runes) && unicode.IsLower(runes[i+1])
+			if unicode.IsLower(previous) || unicode.IsDigit(previous) || (unicode.IsUpper(previous) && nextIsLower) {
+				joined.WriteString(separator)
+			}
+		}
+		joined.WriteRune(unicode.ToLower(r))
+	}
+	return joined.String()
+}
@@ -0,0 +1,72 @@
+package main
+
+import (
+	"encoding/json"
+	"fmt"
+	"math"
+	"strconv"
+
+	"github.com/RedVentures/protoc-gen-jsonschema/internal/jsonschema"
+	"github.com/golang/protobuf/protoc-gen-go/descriptor"
+	"github.com/xeipuuv/gojsonschema"
+)
+
+// floatValueMode is which JSON values are allowed for float and double fields (set with the float_values parameter).
+type floatValueMode int
+
+const (
+	floatValuesNumber    floatValueMode = iota // Any JSON number (the default)
+	floatValuesProtojson                       // Anything protojson accepts (numbers, numeric strings, "NaN", "Infinity" and "-Infinity")
+	floatValuesStrict                          // Only JSON numbers which fit in the type (so no strings, and floats are limited to 32 bits)
+)
+
+var floatValueModeNames = map[floatValueMode]string{
+	floatValuesNumber:    "number",
+	floatValuesProtojson: "protojson",
+	floatValuesStrict:    "strict",
+}
+
+func (m floatValueMode) String() string {
+	return floatValueModeNames[m]
+}
+
+// Set parses a float value mode (this makes it a flag.Value).
+func (m *floatValueMode) Set(name string) error {
+	for mode, modeName := range floatValueModeNames {
+		if modeName == name {
+			*m = mode
+			return nil
+		}
+	}
+	return fmt.Errorf("unsupported float values %q (expected number, protojson or strict)", name)
+}
+
+// Matches the strings protojson accepts for floats and doubles (the special values, or a JSON number in a string):
+const floatStringPattern = `^(NaN|-?Infinity|-?(0|[1-9][0-9]*)(\.[0-9]+)?([eE][+-]?[0-9]+)?)$`
+
+// The largest (finite) 32-bit float:
+var maxFloat32 = json.Number(strconv.FormatFloat(math.MaxFloat32, 'g', -1, 64))
+
+// Returns the JSON-Schemas for the values a float or double field can have (the number, followed by the string form
+// which protojson also accepts):
+func floatTypes(fieldType descriptor.FieldDescriptorProto_Type) []*jsonschema.Type {
+	numberJSONSchemaType := &jsonschema.Type{Type: gojsonschema.TYPE_NUMBER}
+	if fieldType == descriptor.FieldDescriptorProto_TYPE_FLOAT && floatValues != floatValuesNumber {
+		numberJSONSchemaType.Minimum, numberJSONSchemaType.Maximum = "-"+maxFloat32, maxFloat32
+	}
+	if floatFormat {
+		if fieldType == descriptor.FieldDescriptorProto_TYPE_FLOAT {
+			numberJSONSchemaType.Format = "float"
+		} else {
+			numberJSONSchemaType.Format = "double"
+		}
+	}
+
+	if floatValues != floatValuesProtojson || disallowOneOf {
+		return []*jsonschema.Type{numberJSONSchemaType}
+	}
+	return []*jsonschema.Type{
+		numberJSONSchemaType,
+		{Type: gojsonschema.TYPE_STRING, Pattern: floatStringPattern},
+	}
+}
@@ -0,0 +1,49 @@
+package main
+
+import (
+	"fmt"
+
+	"github.com/RedVentures/protoc-gen-jsonschema/internal/jsonschema"
+)
+
+// outputFormat is what the schemas are written out as (set with the format parameter).
+type outputFormat int
+
+const (
+	formatJSON outputFormat = iota // Indented JSON (the default)
+	formatYAML                     // YAML, with the keywords in the same order as in JSON
+)
+
+var outputFormatNames = map[outputFormat]string{
+	formatJSON: "json",
+	formatYAML: "yaml",
+}
+
+// The extension of the files each format is written to (unless the filename parameter says otherwise):
+var outputFormatExtensions = map[outputFormat]string{
+	formatJSON: "jsonschema",
+	formatYAML: "yaml",
+}
+
+func (f outputFormat) String() string {
+	return outputFormatNames[f]
+}
+
+// Set parses an output format (this makes it a flag.Value).
+func (f *outputFormat) Set(name string) error {
+	for format, formatName := range outputFormatNames {
+		if formatName == name {
+			*f = format
+			return nil
+		}
+	}
+	return fmt.Errorf("unsupported format %q (expected json or yaml)", name)
+}
+
+// Writes a JSON-Schema out in the output format:
+func marshalJSONSchema(jsonSchemaType *jsonschema.Type) ([]byte, error) {
+	if schemaFormat == formatYAML {
+		return jsonschema.MarshalYAML(jsonSchemaType, schemaDraft)
+	}
+	return jsonschema.MarshalIndent(jsonSchemaType, schemaDraft, "", "    ")
+}
@@ -1,7 +1,6 @@
 module github.com/RedVentures/protoc-gen-jsonschema
 
 require (
-	github.com/alecthomas/jsonschema v0.0.0-20180308105923-f2c93856175a
 	github.com/golang/protobuf v1.2.0
 	github.com/sirupsen/logrus v1.1.0
 	github.com/stretchr/objx v0.2.0 // indirect
@@ -0,0 +1,436 @@
+// Package jsonschema is a model of JSON-Schema which can be written out for any of the drafts we support
+// (draft-04, draft-06, draft-07, 2019-09 and 2020-12), using the right keywords for each of them.
+package jsonschema
+
+import (
+	"bytes"
+	"encoding/json"
+	"fmt"
+	"math"
+	"reflect"
+	"sort"
+	"strconv"
+	"strings"
+)
+
+// Draft is a version of the JSON-Schema specification.
+type Draft int
+
+// The drafts we can write schemas for (in the order they were published):
+const (
+	Draft04 Draft = iota
+	Draft06
+	Draft07
+	Draft201909
+	Draft202012
+)
+
+var draftNames = map[Draft]string{
+	Draft04:     "draft-04",
+	Draft06:     "draft-06",
+	Draft07:     "draft-07",
+	Draft201909: "2019-09",
+	Draft202012: "2020-12",
+}
+
+var draftVersions = map[Draft]string{
+	Draft04:     "http://json-schema.org/draft-04/schema#",
+	Draft06:     "http://json-schema.org/draft-06/schema#",
+	Draft07:     "http://json-schema.org/draft-07/schema#",
+	Draft201909: "https://json-schema.org/draft/2019-09/schema",
+	Draft202012: "https://json-schema.org/draft/2020-12/schema",
+}
+
+// String returns the name of the draft (eg "draft-07" or "2020-12").
+func (d Draft) String() string {
+	return draftNames[d]
+}
+
+// Set parses the name of a draft (so a Draft can be used as a flag).
+func (d *Draft) Set(name string) error {
+	for draft, draftName := range draftNames {
+		if name == draftName {
+			*d = draft
+			return nil
+		}
+	}
+
+	var names []string
+	for draft := Draft04; draft <= Draft202012; draft++ {
+		names = append(names, draft.String())
+	}
+	return fmt.Errorf("unsupported JSON-Schema draft %q (use one of %s)", name, strings.Join(names, ", "))
+}
+
+// Version returns the meta-schema URI of the draft (for "$schema").
+func (d Draft) Version() string {
+	return draftVersions[d]
+}
+
+// DefinitionsRef returns the "$ref" for a schema in the definitions of the root schema.
+func (d Draft) DefinitionsRef(name string) string {
+	if d >= Draft201909 {
+		return "#/$defs/" + name
+	}
+	return "#/definitions/" + name
+}
+
+// Definitions holds schemas which are referenced with "$ref".
+type Definitions map[string]*Type
+
+// Type is a JSON-Schema. Keywords which only exist in newer drafts are converted (as far as possible) when written
+// out for older ones, and numbers are kept as JSON numbers so that they can hold the full range of 64-bit integers.
+type Type struct {
+	Version               string           // "$schema"
+	ID                    string           // "$id" ("id" before draft-06)
+	Ref                   string           // "$ref"
+	Maximum               json.Number      // Inclusive upper bound
+	ExclusiveMaximum      json.Number      // Exclusive upper bound (a boolean modifying "maximum" before draft-06)
+	Minimum               json.Number      // Inclusive lower bound
+	ExclusiveMinimum      json.Number      // Exclusive lower bound (a boolean modifying "minimum" before draft-06)
+	MaxLength             *int             // Upper bound on the length of strings
+	MinLength             *int             // Lower bound on the length of strings
+	Pattern               string           // Regular expression that strings have to match
+	PrefixItems           []*Type          // Schemas for the first items of arrays ("items" as a list before 2020-12)
+	Items                 *Type            // Schema for the (rest of the) items of arrays
+	MaxItems              *int             // Upper bound on the length of arrays
+	MinItems              *int             // Lower bound on the length of arrays
+	UniqueItems           bool             // Whether the items of arrays have to be unique
+	MaxProperties         *int             // Upper bound on the number of properties of objects
+	MinProperties         *int             // Lower bound on the number of properties of objects
+	Required              []string         // Properties which objects have to have
+	Properties            map[string]*Type // Schemas for properties of objects
+	PropertyOrder         []string         // Order to write the properties out in (the rest are written alphabetically)
+	PatternProperties     map[string]*Type // Schemas for properties of objects with names matching a pattern
+	PropertyNames         *Type            // Schema for the names of properties (draft-06 onwards)
+	AdditionalProperties  *Type            // Schema for any other properties of objects
+	UnevaluatedProperties *Type            // Schema for properties not evaluated by any subschema (2019-09 onwards)
+	Enum                  []interface{}    // Allowed values
+	Const                 interface{}      // The only allowed value ("enum" with one value before draft-06)
+	Type                  string           // JSON type (eg "string" or "object")
+	AllOf                 []*Type          // Schemas which all have to match
+	AnyOf                 []*Type          // Schemas of which at least one has to match
+	OneOf                 []*Type          // Schemas of which exactly one has to match
+	Not                   *Type            // Schema which must not match
+	Definitions           Definitions      // Schemas referenced with "$ref" ("$defs" from 2019-09 onwards)
+	Title                 string
+	Description           string
+	Default               interface{}
+	Format                string // Semantic format of strings (eg "date-time" or "email")
+	ContentEncoding       string // Encoding of binary data in strings (eg "base64", draft-07 onwards)
+
+	boolean *bool // Set for the boolean schemas (true and false)
+}
+
+// Bool returns one of the boolean schemas (true allows anything, false allows nothing).
+func Bool(b bool) *Type {
+	return &Type{boolean: &b}
+}
+
+// MarshalIndent writes a schema out as indented JSON for a particular draft.
+func MarshalIndent(t *Type, draft Draft, prefix, indent string) ([]byte, error) {
+	return json.MarshalIndent(t.encode(draft), prefix, indent)
+}
+
+// encodedType is how a Type is written out (the order of the fields is the order of the keywords in the JSON).
+type encodedType struct {
+	Version               string                 `json:"$schema,omitempty"`
+	ID                    string                 `json:"$id,omitempty"`
+	LegacyID              string                 `json:"id,omitempty"`
+	Ref                   string                 `json:"$ref,omitempty"`
+	Maximum               json.Number            `json:"maximum,omitempty"`
+	ExclusiveMaximum      interface{}            `json:"exclusiveMaximum,omitempty"`
+	Minimum               json.Number            `json:"minimum,omitempty"`
+	ExclusiveMinimum      interface{}            `json:"exclusiveMinimum,omitempty"`
+	MaxLength             *int                   `json:"maxLength,omitempty"`
+	MinLength             *int                   `json:"minLength,omitempty"`
+	Pattern               string                 `json:"pattern,omitempty"`
+	PrefixItems           []interface{}          `json:"prefixItems,omitempty"`
+	Items                 interface{}            `json:"items,omitempty"`
+	AdditionalItems       interface{}            `json:"additionalItems,omitempty"`
+	MaxItems              *int                   `json:"maxItems,omitempty"`
+	MinItems              *int                   `json:"minItems,omitempty"`
+	UniqueItems           bool                   `json:"uniqueItems,omitempty"`
+	MaxProperties         *int                   `json:"maxProperties,omitempty"`
+	MinProperties         *int                   `json:"minProperties,omitempty"`
+	Required              []string               `json:"required,omitempty"`
+	Properties            *orderedMap            `json:"properties,omitempty"`
+	PatternProperties     map[string]interface{} `json:"patternProperties,omitempty"`
+	PropertyNames         interface{}            `json:"propertyNames,omitempty"`
+	AdditionalProperties  interface{}            `json:"additionalProperties,omitempty"`
+	UnevaluatedProperties interface{}            `json:"unevaluatedProperties,omitempty"`
+	Enum                  []interface{}          `json:"enum,omitempty"`
+	Const                 interface{}            `json:"const,omitempty"`
+	Type                  string                 `json:"type,omitempty"`
+	AllOf                 []interface{}          `json:"allOf,omitempty"`
+	AnyOf                 []interface{}          `json:"anyOf,omitempty"`
+	OneOf                 []interface{}          `json:"oneOf,omitempty"`
+	Not                   interface{}            `json:"not,omitempty"`
+	Definitions           map[string]interface{} `json:"definitions,omitempty"`
+	Defs                  map[string]interface{} `json:"$defs,omitempty"`
+	Title                 string                 `json:"title,omitempty"`
+	Description           string                 `json:"description,omitempty"`
+	Default               interface{}            `json:"default,omitempty"`
+	Format                string                 `json:"format,omitempty"`
+	ContentEncoding       string                 `json:"contentEncoding,omitempty"`
+}
+
+// Converts a schema into what gets written out for a particular draft (nil schemas are left out):
+func (t *Type) encode(draft Draft) interface{} {
+	if t == nil {
+		return nil
+	}
+	if t.boolean != nil {
+		return *t.boolean
+	}
+
+	encoded := &encodedType{
+		Version:               t.Version,
+		Ref:                   t.Ref,
+		Maximum:               t.Maximum,
+		Minimum:               t.Minimum,
+		MaxLength:             t.MaxLength,
+		MinLength:             t.MinLength,
+		Pattern:               t.Pattern,
+		Items:                 t.Items.encode(draft),
+		MaxItems:              t.MaxItems,
+		MinItems:              t.MinItems,
+		UniqueItems:           t.UniqueItems,
+		MaxProperties:         t.MaxProperties,
+		MinProperties:         t.MinProperties,
+		Required:              t.Required,
+		Properties:            encodeProperties(t, draft),
+		PatternProperties:     encodeMap(t.PatternProperties, draft),
+		PropertyNames:         t.PropertyNames.encode(draft),
+		AdditionalProperties:  t.AdditionalProperties.encode(draft),
+		UnevaluatedProperties: t.UnevaluatedProperties.encode(draft),
+		Enum:                  t.Enum,
+		Const:                 t.Const,
+		Type:                  t.Type,
+		AllOf:                 encodeList(t.AllOf, draft),
+		AnyOf:                 encodeList(t.AnyOf, draft),
+		OneOf:                 encodeList(t.OneOf, draft),
+		Not:                   t.Not.encode(draft),
+		Title:                 t.Title,
+		Description:           t.Description,
+		Default:               t.Default,
+		Format:                t.Format,
+	}
+
+	// Identifiers:
+	if draft >= Draft06 {
+		encoded.ID = t.ID
+	} else {
+		encoded.LegacyID = t.ID
+	}
+
+	// Bounds:
+	if draft >= Draft06 {
+		encoded.ExclusiveMaximum = encodeNumber(t.ExclusiveMaximum)
+		encoded.ExclusiveMinimum = encodeNumber(t.ExclusiveMinimum)
+	} else {
+		// Before draft-06, exclusive bounds were "maximum" / "minimum" with a boolean flag
+		// (so an exclusive bound takes the place of an inclusive one):
+		if t.ExclusiveMaximum != "" {
+			encoded.Maximum, encoded.ExclusiveMaximum = t.ExclusiveMaximum, true
+		}
+		if t.ExclusiveMinimum != "" {
+			encoded.Minimum, encoded.ExclusiveMinimum = t.ExclusiveMinimum, true
+		}
+	}
+
+	// Arrays:
+	if len(t.PrefixItems) > 0 {
+		if draft >= Draft202012 {
+			encoded.PrefixItems = encodeList(t.PrefixItems, draft)
+		} else {
+			// Before 2020-12, "items" was a list for this (with "additionalItems" for the rest of the items):
+			encoded.Items = encodeList(t.PrefixItems, draft)
+			encoded.AdditionalItems = t.Items.encode(draft)
+		}
+	}
+
+	// Objects:
+	if draft < Draft06 && t.PropertyNames != nil {
+		// Before draft-06 the names of properties could only be constrained with a pattern (for the properties
+		// which aren't described otherwise):
+		encoded.PropertyNames = nil
+		if t.PropertyNames.Pattern != "" {
+			var patternSchema interface{} = &encodedType{}
+			if t.AdditionalProperties != nil && t.AdditionalProperties.boolean == nil {
+				patternSchema = encoded.AdditionalProperties
+			}
+			if encoded.PatternProperties == nil {
+				encoded.PatternProperties = make(map[string]interface{})
+			}
+			encoded.PatternProperties[t.PropertyNames.Pattern] = patternSchema
+			encoded.AdditionalProperties = false
+		}
+	}
+	if draft < Draft201909 && t.UnevaluatedProperties != nil {
+		// Before 2019-09 the closest thing is "additionalProperties" (which doesn't see into subschemas):
+		encoded.UnevaluatedProperties = nil
+		if encoded.AdditionalProperties == nil {
+			encoded.AdditionalProperties = t.UnevaluatedProperties.encode(draft)
+		}
+	}
+
+	// Values:
+	if draft < Draft06 && t.Const != nil {
+		// Before draft-06, a single allowed value was an "enum" of one:
+		encoded.Const = nil
+		if len(encoded.Enum) == 0 {
+			encoded.Enum = []interface{}{t.Const}
+		} else {
+			encoded.AllOf = append(encoded.AllOf, &encodedType{Enum: []interface{}{t.Const}})
+		}
+	}
+
+	// Strings (there's no way to say how binary data is encoded before draft-07, so that is left out):
+	if draft >= Draft07 {
+		encoded.ContentEncoding = t.ContentEncoding
+	}
+
+	// Definitions:
+	if draft >= Draft201909 {
+		encoded.Defs = encodeMap(t.Definitions, draft)
+	} else {
+		encoded.Definitions = encodeMap(t.Definitions, draft)
+	}
+
+	// References (before 2019-09 anything next to a "$ref" was ignored, so other keywords are combined with it in an
+	// "allOf" instead):
+	if draft < Draft201909 && t.Ref != "" {
+		return encodeRef(encoded)
+	}
+
+	return encoded
+}
+
+// Moves the keywords next to a "$ref" into an "allOf" with it (identifiers, annotations and definitions stay put):
+func encodeRef(encoded *encodedType) *encodedType {
+	constraints := *encoded
+	constraints.Version, constraints.ID, constraints.LegacyID, constraints.Ref = "", "", "", ""
+	constraints.Title, constraints.Description, constraints.Default = "", "", nil
+	constraints.Definitions = nil
+	if reflect.DeepEqual(constraints, encodedType{}) {
+		return encoded
+	}
+	return &encodedType{
+		Version:     encoded.Version,
+		ID:          encoded.ID,
+		LegacyID:    encoded.LegacyID,
+		AllOf:       []interface{}{&encodedType{Ref: encoded.Ref}, &constraints},
+		Definitions: encoded.Definitions,
+		Title:       encoded.Title,
+		Description: encoded.Description,
+		Default:     encoded.Default,
+	}
+}
+
+func encodeList(types []*Type, draft Draft) []interface{} {
+	var encoded []interface{}
+	for _, t := range types {
+		encoded = append(encoded, t.encode(draft))
+	}
+	return encoded
+}
+
+func encodeMap(types map[string]*Type, draft Draft) map[string]interface{} {
+	if len(types) == 0 {
+		return nil
+	}
+	encoded := make(map[string]interface{}, len(types))
+	for name, t := range types {
+		encoded[name] = t.encode(draft)
+	}
+	return encoded
+}
+
+// orderedMap is a JSON object which keeps its keys in order (maps are always written out with sorted keys).
+type orderedMap struct {
+	keys   []string
+	values map[string]interface{}
+}
+
+// MarshalJSON writes the object out with its keys in order.
+func (m *orderedMap) MarshalJSON() ([]byte, error) {
+	var buf bytes.Buffer
+	buf.WriteByte('{')
+	for i, key := range m.keys {
+		if i > 0 {
+			buf.WriteByte(',')
+		}
+		encodedKey, err := json.Marshal(key)
+		if err != nil {
+			return nil, err
+		}
+		encodedValue, err := json.Marshal(m.values[key])
+		if err != nil {
+			return nil, err
+		}
+		buf.Write(encodedKey)
+		buf.WriteByte(':')
+		buf.Write(encodedValue)
+	}
+	buf.WriteByte('}')
+	return buf.Bytes(), nil
+}
+
+// Encodes the properties of a schema in their order (followed by any which weren't given an order, alphabetically):
+func encodeProperties(t *Type, draft Draft) *orderedMap {
+	if len(t.Properties) == 0 {
+		return nil
+	}
+	encoded := &orderedMap{values: encodeMap(t.Properties, draft)}
+	ordered := make(map[string]bool, len(t.PropertyOrder))
+	for _, name := range t.PropertyOrder {
+		if _, ok := t.Properties[name]; ok && !ordered[name] {
+			encoded.keys = append(encoded.keys, name)
+			ordered[name] = true
+		}
+	}
+	var unordered []string
+	for name := range t.Properties {
+		if !ordered[name] {
+			unordered = append(unordered, name)
+		}
+	}
+	sort.Strings(unordered)
+	encoded.keys = append(encoded.keys, unordered...)
+	return encoded
+}
+
+// Leaves out empty numbers (which would otherwise be written out as an interface holding ""):
+func encodeNumber(number json.Number) interface{} {
+	if number == "" {
+		return nil
+	}
+	return number
+}
+
+// Count returns a length or a number of items or properties (these are only written out when they're set, so an
+// explicit 0 is kept).
+func Count(n uint64) *int {
+	count := int(n)
+	return &count
+}
+
+// Int returns an integer as a JSON number.
+func Int(i int64) json.Number {
+	return json.Number(strconv.FormatInt(i, 10))
+}
+
+// Uint returns an unsigned integer as a JSON number.
+func Uint(u uint64) json.Number {
+	return json.Number(strconv.FormatUint(u, 10))
+}
+
+// Float returns a float as a JSON number (bitSize is 32 for floats, so that they don't gain spurious digits).
+// JSON has no way to write NaN or infinity, so they can't be used.
+func Float(f float64, bitSize int) (json.Number, error) {
+	if math.IsNaN(f) || math.IsInf(f, 0) {
+		return "", fmt.Errorf("%v can't be written as a JSON number", f)
+	}
+	return json.Number(strconv.FormatFloat(f, 'f', -1, bitSize)), nil
+}
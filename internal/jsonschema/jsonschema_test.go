@@ -0,0 +1,124 @@
+package jsonschema
+
+import (
+	"encoding/json"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+// Returns a schema using keywords which are written out differently for each draft:
+func draftSensitiveType(draft Draft) *Type {
+	return &Type{
+		Version: draft.Version(),
+		ID:      "https://example.com/schemas/Sample.jsonschema",
+		Type:    "object",
+		Properties: map[string]*Type{
+			"count": {Type: "integer", Maximum: "10", ExclusiveMinimum: "0"},
+			"data":  {Type: "string", ContentEncoding: "base64"},
+			"item":  {Ref: draft.DefinitionsRef("Item"), MinProperties: Count(1)},
+			"kind":  {Type: "string", Const: "user"},
+			"tuple": {Type: "array", PrefixItems: []*Type{{Type: "string"}}, Items: Bool(false)},
+		},
+		UnevaluatedProperties: Bool(false),
+		Definitions: Definitions{
+			"Item": {Type: "object"},
+		},
+	}
+}
+
+func TestEncodeDrafts(t *testing.T) {
+	for draft, expectedJSON := range map[Draft]string{
+		Draft04: `{
+			"$schema": "http://json-schema.org/draft-04/schema#",
+			"id": "https://example.com/schemas/Sample.jsonschema",
+			"properties": {
+				"count": {"maximum": 10, "minimum": 0, "exclusiveMinimum": true, "type": "integer"},
+				"data": {"type": "string"},
+				"item": {"allOf": [{"$ref": "#/definitions/Item"}, {"minProperties": 1}]},
+				"kind": {"enum": ["user"], "type": "string"},
+				"tuple": {"items": [{"type": "string"}], "additionalItems": false, "type": "array"}
+			},
+			"additionalProperties": false,
+			"type": "object",
+			"definitions": {"Item": {"type": "object"}}
+		}`,
+		Draft06: `{
+			"$schema": "http://json-schema.org/draft-06/schema#",
+			"$id": "https://example.com/schemas/Sample.jsonschema",
+			"properties": {
+				"count": {"maximum": 10, "exclusiveMinimum": 0, "type": "integer"},
+				"data": {"type": "string"},
+				"item": {"allOf": [{"$ref": "#/definitions/Item"}, {"minProperties": 1}]},
+				"kind": {"const": "user", "type": "string"},
+				"tuple": {"items": [{"type": "string"}], "additionalItems": false, "type": "array"}
+			},
+			"additionalProperties": false,
+			"type": "object",
+			"definitions": {"Item": {"type": "object"}}
+		}`,
+		Draft07: `{
+			"$schema": "http://json-schema.org/draft-07/schema#",
+			"$id": "https://example.com/schemas/Sample.jsonschema",
+			"properties": {
+				"count": {"maximum": 10, "exclusiveMinimum": 0, "type": "integer"},
+				"data": {"type": "string", "contentEncoding": "base64"},
+				"item": {"allOf": [{"$ref": "#/definitions/Item"}, {"minProperties": 1}]},
+				"kind": {"const": "user", "type": "string"},
+				"tuple": {"items": [{"type": "string"}], "additionalItems": false, "type": "array"}
+			},
+			"additionalProperties": false,
+			"type": "object",
+			"definitions": {"Item": {"type": "object"}}
+		}`,
+		Draft201909: `{
+			"$schema": "https://json-schema.org/draft/2019-09/schema",
+			"$id": "https://example.com/schemas/Sample.jsonschema",
+			"properties": {
+				"count": {"maximum": 10, "exclusiveMinimum": 0, "type": "integer"},
+				"data": {"type": "string", "contentEncoding": "base64"},
+				"item": {"$ref": "#/$defs/Item", "minProperties": 1},
+				"kind": {"const": "user", "type": "string"},
+				"tuple": {"items": [{"type": "string"}], "additionalItems": false, "type": "array"}
+			},
+			"unevaluatedProperties": false,
+			"type": "object",
+			"$defs": {"Item": {"type": "object"}}
+		}`,
+		Draft202012: `{
+			"$schema": "https://json-schema.org/draft/2020-12/schema",
+			"$id": "https://example.com/schemas/Sample.jsonschema",
+			"properties": {
+				"count": {"maximum": 10, "exclusiveMinimum": 0, "type": "integer"},
+				"data": {"type": "string", "contentEncoding": "base64"},
+				"item": {"$ref": "#/$defs/Item", "minProperties": 1},
+				"kind": {"const": "user", "type": "string"},
+				"tuple": {"prefixItems": [{"type": "string"}], "items": false, "type": "array"}
+			},
+			"unevaluatedProperties": false,
+			"type": "object",
+			"$defs": {"Item": {"type": "object"}}
+		}`,
+	} {
+		encodedJSON, err := json.Marshal(draftSensitiveType(draft).encode(draft))
+		if assert.NoError(t, err, "Unable to encode schema for draft (%v)", draft) {
+			assert.JSONEq(t, expectedJSON, string(encodedJSON), "Incorrect schema for draft (%v)", draft)
+		}
+	}
+}
+
+func TestEncodeRef(t *testing.T) {
+	// A "$ref" with nothing but annotations next to it is left alone:
+	annotatedRef := &Type{Ref: "#/definitions/Item", Title: "Item", Default: "x"}
+	encodedJSON, err := json.Marshal(annotatedRef.encode(Draft04))
+	if assert.NoError(t, err) {
+		assert.JSONEq(t, `{"$ref": "#/definitions/Item", "title": "Item", "default": "x"}`, string(encodedJSON))
+	}
+
+	// Otherwise the annotations stay next to the "allOf" (where they aren't ignored):
+	constrainedRef := &Type{Ref: "#/definitions/Item", Title: "Item", MaxLength: Count(0)}
+	encodedJSON, err = json.Marshal(constrainedRef.encode(Draft07))
+	if assert.NoError(t, err) {
+		assert.JSONEq(t, `{"allOf": [{"$ref": "#/definitions/Item"}, {"maxLength": 0}], "title": "Item"}`, string(encodedJSON))
+	}
+}
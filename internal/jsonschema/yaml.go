@@ -0,0 +1,165 @@
+package jsonschema
+
+import (
+	"bytes"
+	"encoding/json"
+	"fmt"
+	"regexp"
+	"strings"
+)
+
+// MarshalYAML writes a schema out as YAML for a particular draft. The keywords are in the same (stable) order as
+// they are in JSON, and strings are only left unquoted when they can't be mistaken for anything else.
+func MarshalYAML(t *Type, draft Draft) ([]byte, error) {
+	// The JSON encoding decides the order of the keywords, so it is read back in order:
+	encodedJSON, err := json.Marshal(t.encode(draft))
+	if err != nil {
+		return nil, err
+	}
+	decoder := json.NewDecoder(bytes.NewReader(encodedJSON))
+	decoder.UseNumber()
+	value, err := decodeOrdered(decoder)
+	if err != nil {
+		return nil, err
+	}
+
+	var encodedYAML bytes.Buffer
+	if err := writeYAML(&encodedYAML, value, 0); err != nil {
+		return nil, err
+	}
+	return encodedYAML.Bytes(), nil
+}
+
+// A yamlMapping is a JSON object with its keys in the order they were written in:
+type yamlMapping struct {
+	keys   []string
+	values []interface{}
+}
+
+// Reads the next JSON value (objects become yamlMappings, arrays []interface{} and everything else stays a token):
+func decodeOrdered(decoder *json.Decoder) (interface{}, error) {
+	token, err := decoder.Token()
+	if err != nil {
+		return nil, err
+	}
+	switch token {
+	case json.Delim('{'):
+		mapping := &yamlMapping{}
+		for decoder.More() {
+			key, err := decoder.Token()
+			if err != nil {
+				return nil, err
+			}
+			value, err := decodeOrdered(decoder)
+			if err != nil {
+				return nil, err
+			}
+			mapping.keys = append(mapping.keys, key.(string))
+			mapping.values = append(mapping.values, value)
+		}
+		_, err := decoder.Token()
+		return mapping, err
+	case json.Delim('['):
+		sequence := []interface{}{}
+		for decoder.More() {
+			value, err := decodeOrdered(decoder)
+			if err != nil {
+				return nil, err
+			}
+			sequence = append(sequence, value)
+		}
+		_, err := decoder.Token()
+		return sequence, err
+	}
+	return token, nil
+}
+
+// Writes a value out as a block of YAML lines (each indented by the given number of spaces):
+func writeYAML(buf *bytes.Buffer, value interface{}, indent int) error {
+	prefix := strings.Repeat(" ", indent)
+	switch value := value.(type) {
+	case *yamlMapping:
+		for i, key := range value.keys {
+			buf.WriteString(prefix + yamlScalar(key) + ":")
+			if err := writeYAMLValue(buf, value.values[i], indent); err != nil {
+				return err
+			}
+		}
+	case []interface{}:
+		for _, item := range value {
+			if !isYAMLBlock(item) {
+				buf.WriteString(prefix + "-")
+				if err := writeYAMLValue(buf, item, indent); err != nil {
+					return err
+				}
+				continue
+			}
+			// Blocks start on the same line as their "-" (eg "- type: string"):
+			var block bytes.Buffer
+			if err := writeYAML(&block, item, indent+2); err != nil {
+				return err
+			}
+			buf.WriteString(prefix + "- ")
+			buf.Write(block.Bytes()[indent+2:])
+		}
+	default:
+		buf.WriteString(prefix + yamlScalar(value) + "\n")
+	}
+	return nil
+}
+
+// Writes the rest of a line which ends in a key (or a "-"), followed by the lines of its value (if it is a block):
+func writeYAMLValue(buf *bytes.Buffer, value interface{}, indent int) error {
+	if !isYAMLBlock(value) {
+		buf.WriteString(" " + yamlScalar(value) + "\n")
+		return nil
+	}
+	buf.WriteString("\n")
+	return writeYAML(buf, value, indent+2)
+}
+
+// Returns whether a value is written out as a block of lines (empty objects and arrays fit on one line, as "{}" and "[]"):
+func isYAMLBlock(value interface{}) bool {
+	switch collection := value.(type) {
+	case *yamlMapping:
+		return len(collection.keys) > 0
+	case []interface{}:
+		return len(collection) > 0
+	}
+	return false
+}
+
+// Strings which can be written without quotes (anything else could be mistaken for another type, or YAML syntax):
+var plainYAMLStringRegexp = regexp.MustCompile(`^[A-Za-z_$][A-Za-z0-9_$./-]*$`)
+
+// Plain strings which YAML (1.1 or 1.2) would read as something else:
+var reservedYAMLWords = map[string]bool{
+	"y": true, "yes": true, "n": true, "no": true, "true": true, "false": true, "on": true, "off": true, "null": true,
+}
+
+// Writes out a single JSON value (which is never a block, so collections are empty):
+func yamlScalar(value interface{}) string {
+	switch value := value.(type) {
+	case *yamlMapping:
+		return "{}"
+	case []interface{}:
+		return "[]"
+	case nil:
+		return "null"
+	case bool:
+		return fmt.Sprint(value)
+	case json.Number:
+		return value.String()
+	case string:
+		if plainYAMLStringRegexp.MatchString(value) && !reservedYAMLWords[strings.ToLower(value)] {
+			return value
+		}
+		// JSON strings are valid double-quoted YAML strings:
+		var quoted bytes.Buffer
+		encoder := json.NewEncoder(&quoted)
+		encoder.SetEscapeHTML(false)
+		encoder.Encode(value)
+		return strings.TrimSuffix(quoted.String(), "\n")
+	}
+	return fmt.Sprint(value)
+}
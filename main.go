@@ -3,8 +3,8 @@
 // "Heavily influenced" by Google's "protog-gen-bq-schema"
 //
 // usage:
-//  $ bin/protoc --jsonschema_out=path/to/outdir foo.proto
 //
+//	$ bin/protoc --jsonschema_out=path/to/outdir foo.proto
 package main
 
 import (
@@ -14,11 +14,12 @@ import (
 	"io"
 	"io/ioutil"
 	"log"
+	"math"
 	"os"
 	"path"
 	"strings"
 
-	"github.com/alecthomas/jsonschema"
+	"github.com/RedVentures/protoc-gen-jsonschema/internal/jsonschema"
 	"github.com/golang/protobuf/proto"
 	"github.com/golang/protobuf/protoc-gen-go/descriptor"
 	plugin "github.com/golang/protobuf/protoc-gen-go/plugin"
@@ -36,16 +37,36 @@ const (
 
 var (
 	allowNullValues              bool
+	base64Bytes                  bool
+	baseURI                      string
+	bundling                     bundleMode
 	disallowEnumOneOf            bool
 	disallowOneOf                bool
 	disallowAdditionalProperties bool
 	disallowBigIntsAsStrings     bool
+	enforceOneOf                 bool
+	externalRefs                 externalRefMode
+	floatFormat                  bool
+	floatValues                  floatValueMode
+	outputFileNameTemplate       fileNameTemplate
+	generateNestedTypes          bool
+	outputPaths                  outputPathLayout
+	propertyOrder                propertyOrdering
+	propertyNames                propertyNaming
+	requireProto3Scalars         bool
+	useDefinitions               bool
+	usePGVRules                  bool
+	schemaDraft                  jsonschema.Draft
+	schemaFormat                 outputFormat
+	excludeCommentPrefixes       stringList
 	debugLogging                 bool
+	messageSyntaxes              = make(map[*descriptor.DescriptorProto]string)
 	globalPkg                    = &ProtoPackage{
 		name:     "",
 		parent:   nil,
 		children: make(map[string]*ProtoPackage),
 		types:    make(map[string]*descriptor.DescriptorProto),
+		enums:    make(map[string]*descriptor.EnumDescriptorProto),
 	}
 	logLevels = map[LogLevel]string{
 		0: "DEBUG",
@@ -63,16 +84,45 @@ type ProtoPackage struct {
 	parent   *ProtoPackage
 	children map[string]*ProtoPackage
 	types    map[string]*descriptor.DescriptorProto
+	enums    map[string]*descriptor.EnumDescriptorProto
+}
+
+// conversionState is shared by everything converted on behalf of a single root message (or bundle).
+type conversionState struct {
+	file           *descriptor.FileDescriptorProto // The proto file the root message was declared in
+	outputFileName string                          // The file the root message is being converted into
+	rootName       string                          // Fully qualified name of the root message (eg ".samples.TreeNode")
+	converting     map[string]bool                 // Messages currently being converted (to detect recursion)
+	definitions    jsonschema.Definitions          // Messages and enums which are referenced with "$ref"
+	useDefinitions bool                            // Whether every message and enum is referenced from the definitions
 }
 
 type LogLevel int
 
 func init() {
 	flag.BoolVar(&allowNullValues, "allow_null_values", false, "Allow NULL values to be validated")
+	flag.BoolVar(&base64Bytes, "base64_bytes", false, "Only allow base64 encoded strings for bytes fields")
+	flag.StringVar(&baseURI, "base_uri", "", "Give every schema an $id under this URI (eg https://example.com/schemas/)")
+	flag.Var(&bundling, "bundle", "Bundle schemas into one document for each proto file or package (none, file or package)")
 	flag.BoolVar(&disallowEnumOneOf, "disallow_enum_one_of", false, "Disallows enums to have number value as well as name value")
 	flag.BoolVar(&disallowOneOf, "disallow_one_of", false, "Disallows oneOf types")
 	flag.BoolVar(&disallowAdditionalProperties, "disallow_additional_properties", false, "Disallow additional properties")
 	flag.BoolVar(&disallowBigIntsAsStrings, "disallow_bigints_as_strings", false, "Disallow bigints to be strings (eg scientific notation)")
+	flag.BoolVar(&enforceOneOf, "enforce_oneof", false, "Require exactly one field of each proto oneof to be set")
+	flag.Var(&externalRefs, "external_refs", "Refer to messages and enums from other proto files with a $ref to their files (none, relative or absolute)")
+	flag.Var(&outputFileNameTemplate, "filename", "Template for output filenames, with {package}, {file}, {message}, {version} and {extension} placeholders (eg \"{message|kebab}.schema.json\")")
+	flag.BoolVar(&floatFormat, "float_format", false, "Give float and double fields a \"float\" or \"double\" format")
+	flag.Var(&floatValues, "float_values", "Values allowed for float and double fields (number, protojson or strict)")
+	flag.BoolVar(&generateNestedTypes, "generate_nested_types", false, "Generate a JSON-Schema file for every nested message and enum")
+	flag.Var(&outputPaths, "paths", "Where to write output files (flat, package, source_relative or fully_qualified)")
+	flag.Var(&propertyOrder, "property_order", "Order to write properties out in (alphabetical, field_number or declaration)")
+	flag.Var(&propertyNames, "proto_names", "Name properties after proto fields instead of their json_name (or \"both\" to accept either)")
+	flag.BoolVar(&requireProto3Scalars, "require_proto3_scalars", false, "Require proto3 scalar fields without presence to be present")
+	flag.BoolVar(&useDefinitions, "use_definitions", false, "Reference nested messages and enums from a shared definitions block")
+	flag.BoolVar(&usePGVRules, "use_pgv_rules", false, "Translate protoc-gen-validate (validate.rules) field options into JSON-Schema keywords")
+	flag.Var(&schemaFormat, "format", "Format to write schemas out in (json or yaml)")
+	flag.Var(&schemaDraft, "draft", "JSON-Schema draft to generate (draft-04, draft-06, draft-07, 2019-09 or 2020-12)")
+	flag.Var(&excludeCommentPrefixes, "exclude_comment_prefix", "Leave comment lines starting with this prefix (eg @exclude) out of descriptions")
 	flag.BoolVar(&debugLogging, "debug", false, "Log debug messages")
 }
 
@@ -88,6 +138,18 @@ func logWithLevel(logLevel LogLevel, logFormat string, logParams ...interface{})
 }
 
 func registerType(pkgName *string, msg *descriptor.DescriptorProto) {
+	pkg := registerPackage(pkgName)
+	pkg.types[msg.GetName()] = msg
+}
+
+// Registers a top-level enum in its package (nested enums are found through the messages they are nested in):
+func registerEnum(pkgName *string, enum *descriptor.EnumDescriptorProto) {
+	pkg := registerPackage(pkgName)
+	pkg.enums[enum.GetName()] = enum
+}
+
+// Returns the package with the given name, creating it (and its parents) if necessary:
+func registerPackage(pkgName *string) *ProtoPackage {
 	pkg := globalPkg
 	if pkgName != nil {
 		for _, node := range strings.Split(*pkgName, ".") {
@@ -102,13 +164,22 @@ func registerType(pkgName *string, msg *descriptor.DescriptorProto) {
 					parent:   pkg,
 					children: make(map[string]*ProtoPackage),
 					types:    make(map[string]*descriptor.DescriptorProto),
+					enums:    make(map[string]*descriptor.EnumDescriptorProto),
 				}
 				pkg.children[node] = child
 			}
 			pkg = child
 		}
 	}
-	pkg.types[msg.GetName()] = msg
+	return pkg
+}
+
+// Remembers which syntax ("proto2" or "proto3") messages (and their nested messages) were defined with:
+func registerSyntax(syntax string, msgs []*descriptor.DescriptorProto) {
+	for _, msg := range msgs {
+		messageSyntaxes[msg] = syntax
+		registerSyntax(syntax, msg.GetNestedType())
+	}
 }
 
 func (pkg *ProtoPackage) lookupType(name string) (*descriptor.DescriptorProto, bool) {
@@ -167,7 +238,66 @@ func (pkg *ProtoPackage) relativelyLookupType(name string) (*descriptor.Descript
 	}
 }
 
+// Finds an enum with the same scoping rules as lookupType:
+func (pkg *ProtoPackage) lookupEnum(name string) (*descriptor.EnumDescriptorProto, bool) {
+	if strings.HasPrefix(name, ".") {
+		return globalPkg.relativelyLookupEnum(name[1:len(name)])
+	}
+
+	for ; pkg != nil; pkg = pkg.parent {
+		if desc, ok := pkg.relativelyLookupEnum(name); ok {
+			return desc, ok
+		}
+	}
+	return nil, false
+}
+
+func relativelyLookupNestedEnum(desc *descriptor.DescriptorProto, name string) (*descriptor.EnumDescriptorProto, bool) {
+	// Everything before the last component names the message the enum is nested in:
+	if lastDot := strings.LastIndex(name, "."); lastDot >= 0 {
+		var ok bool
+		if desc, ok = relativelyLookupNestedType(desc, name[:lastDot]); !ok {
+			return nil, false
+		}
+		name = name[lastDot+1:]
+	}
+	for _, enum := range desc.GetEnumType() {
+		if enum.GetName() == name {
+			return enum, true
+		}
+	}
+	logWithLevel(LOG_INFO, "no such nested enum %s in %s", name, desc.GetName())
+	return nil, false
+}
+
+func (pkg *ProtoPackage) relativelyLookupEnum(name string) (*descriptor.EnumDescriptorProto, bool) {
+	components := strings.SplitN(name, ".", 2)
+	switch len(components) {
+	case 1:
+		found, ok := pkg.enums[components[0]]
+		return found, ok
+	case 2:
+		if child, ok := pkg.children[components[0]]; ok {
+			found, ok := child.relativelyLookupEnum(components[1])
+			return found, ok
+		}
+		if msg, ok := pkg.types[components[0]]; ok {
+			found, ok := relativelyLookupNestedEnum(msg, components[1])
+			return found, ok
+		}
+		logWithLevel(LOG_INFO, "no such package nor message %s in %s", components[0], pkg.name)
+		return nil, false
+	default:
+		logWithLevel(LOG_DEBUG, "empty enum name")
+		return nil, false
+	}
+}
+
 func (pkg *ProtoPackage) relativelyLookupPackage(name string) (*ProtoPackage, bool) {
+	// Proto files without a package declare everything at the top (like registerPackage does):
+	if name == "" {
+		return pkg, true
+	}
 	components := strings.Split(name, ".")
 	for _, c := range components {
 		var ok bool
@@ -179,12 +309,40 @@ func (pkg *ProtoPackage) relativelyLookupPackage(name string) (*ProtoPackage, bo
 	return pkg, true
 }
 
+func newConversionState(file *descriptor.FileDescriptorProto, msgName string) *conversionState {
+	rootName := "." + msgName
+	if file.GetPackage() != "" {
+		rootName = "." + file.GetPackage() + rootName
+	}
+	return &conversionState{
+		file:           file,
+		outputFileName: outputFileName(file, msgName),
+		rootName:       rootName,
+		converting:     make(map[string]bool),
+		definitions:    make(jsonschema.Definitions),
+		useDefinitions: useDefinitions,
+	}
+}
+
+// Returns a JSON-Schema reference to an entry in the top-level "definitions" (or "$defs"):
+func definitionRef(definitionName string) string {
+	return schemaDraft.DefinitionsRef(definitionName)
+}
+
 // Convert a proto "field" (essentially a type-switch with some recursion):
-func convertField(curPkg *ProtoPackage, desc *descriptor.FieldDescriptorProto, msg *descriptor.DescriptorProto) (*jsonschema.Type, error) {
+func convertField(curPkg *ProtoPackage, desc *descriptor.FieldDescriptorProto, msg *descriptor.DescriptorProto, state *conversionState) (*jsonschema.Type, error) {
 	// Helpers for this inverse logic shit
 	allowEnumOneOf := !disallowEnumOneOf
 	allowOneOf := !disallowOneOf
 
+	// Only fields which track presence can be NULL (meaning "not set"):
+	allowNull := allowNullValues && hasExplicitPresence(desc, msg)
+
+	// Well-known types (Timestamp, Duration, wrappers etc) have their own JSON representations:
+	if wkt, ok := wellKnownTypes[desc.GetTypeName()]; ok {
+		return convertWellKnownTypeField(desc, msg, wkt, allowNull), nil
+	}
+
 	// Prepare a new jsonschema.Type for our eventual return value:
 	jsonSchemaType := &jsonschema.Type{
 		Properties: make(map[string]*jsonschema.Type),
@@ -194,13 +352,13 @@ func convertField(curPkg *ProtoPackage, desc *descriptor.FieldDescriptorProto, m
 	switch desc.GetType() {
 	case descriptor.FieldDescriptorProto_TYPE_DOUBLE,
 		descriptor.FieldDescriptorProto_TYPE_FLOAT:
-		if allowNullValues && allowOneOf {
-			jsonSchemaType.OneOf = []*jsonschema.Type{
-				{Type: gojsonschema.TYPE_NULL},
-				{Type: gojsonschema.TYPE_NUMBER},
-			}
+		floatJSONSchemaTypes := floatTypes(desc.GetType())
+		if allowNull && allowOneOf {
+			jsonSchemaType.OneOf = append([]*jsonschema.Type{{Type: gojsonschema.TYPE_NULL}}, floatJSONSchemaTypes...)
+		} else if len(floatJSONSchemaTypes) > 1 {
+			jsonSchemaType.OneOf = floatJSONSchemaTypes
 		} else {
-			jsonSchemaType.Type = gojsonschema.TYPE_NUMBER
+			jsonSchemaType = floatJSONSchemaTypes[0]
 		}
 
 	case descriptor.FieldDescriptorProto_TYPE_INT32,
@@ -208,7 +366,7 @@ func convertField(curPkg *ProtoPackage, desc *descriptor.FieldDescriptorProto, m
 		descriptor.FieldDescriptorProto_TYPE_FIXED32,
 		descriptor.FieldDescriptorProto_TYPE_SFIXED32,
 		descriptor.FieldDescriptorProto_TYPE_SINT32:
-		if allowNullValues && allowOneOf {
+		if allowNull && allowOneOf {
 			jsonSchemaType.OneOf = []*jsonschema.Type{
 				{Type: gojsonschema.TYPE_NULL},
 				{Type: gojsonschema.TYPE_INTEGER},
@@ -216,6 +374,7 @@ func convertField(curPkg *ProtoPackage, desc *descriptor.FieldDescriptorProto, m
 		} else {
 			jsonSchemaType.Type = gojsonschema.TYPE_INTEGER
 		}
+		applyIntegerRange(jsonSchemaType, desc.GetType())
 
 	case descriptor.FieldDescriptorProto_TYPE_INT64,
 		descriptor.FieldDescriptorProto_TYPE_UINT64,
@@ -228,24 +387,32 @@ func convertField(curPkg *ProtoPackage, desc *descriptor.FieldDescriptorProto, m
 			if !disallowBigIntsAsStrings {
 				jsonSchemaType.OneOf = append(jsonSchemaType.OneOf, &jsonschema.Type{Type: gojsonschema.TYPE_STRING})
 			}
-			if allowNullValues {
+			if allowNull {
 				jsonSchemaType.OneOf = append(jsonSchemaType.OneOf, &jsonschema.Type{Type: gojsonschema.TYPE_NULL})
 			}
 		} else {
 			jsonSchemaType.Type = gojsonschema.TYPE_INTEGER
 		}
+		applyIntegerRange(jsonSchemaType, desc.GetType())
 
 	case descriptor.FieldDescriptorProto_TYPE_STRING,
 		descriptor.FieldDescriptorProto_TYPE_BYTES:
-		if allowNullValues && allowOneOf {
+		stringJSONSchemaType := jsonSchemaType
+		if allowNull && allowOneOf {
+			stringJSONSchemaType = &jsonschema.Type{Type: gojsonschema.TYPE_STRING}
 			jsonSchemaType.OneOf = []*jsonschema.Type{
 				{Type: gojsonschema.TYPE_NULL},
-				{Type: gojsonschema.TYPE_STRING},
+				stringJSONSchemaType,
 			}
 		} else {
 			jsonSchemaType.Type = gojsonschema.TYPE_STRING
 		}
 
+		// Bytes are base64 encoded:
+		if desc.GetType() == descriptor.FieldDescriptorProto_TYPE_BYTES && base64Bytes {
+			applyBase64Encoding(stringJSONSchemaType)
+		}
+
 	case descriptor.FieldDescriptorProto_TYPE_ENUM:
 		// NOTE with the original way this library worked (no concept of `allowEnumOneOf`), enums could pass validation with either
 		// the integer or the string passed in. Well, in the down stream processes (like data lake) these fields are expected to
@@ -256,27 +423,18 @@ func convertField(curPkg *ProtoPackage, desc *descriptor.FieldDescriptorProto, m
 			jsonSchemaType.OneOf = append(jsonSchemaType.OneOf, &jsonschema.Type{Type: gojsonschema.TYPE_STRING})
 			jsonSchemaType.OneOf = append(jsonSchemaType.OneOf, &jsonschema.Type{Type: gojsonschema.TYPE_INTEGER})
 
-			if allowNullValues {
+			if allowNull {
 				jsonSchemaType.OneOf = append(jsonSchemaType.OneOf, &jsonschema.Type{Type: gojsonschema.TYPE_NULL})
 			}
 		} else {
 			jsonSchemaType.Type = gojsonschema.TYPE_STRING
 		}
 
-		foundEnum := false
-		// Go through all the enums we have, see if we can match any to this field by name:
-		for _, enumDescriptor := range msg.GetEnumType() {
-
-			// Is this the enum we care about?
-			if foundEnum || !strings.HasSuffix(desc.GetTypeName(), *enumDescriptor.Name) {
-				continue
-			}
-
-			// Indicate we found what we are looking for
-			foundEnum = true
-
+		// Look the enum up by its (fully qualified) name:
+		enumDescriptorFound, foundEnum := curPkg.lookupEnum(desc.GetTypeName())
+		if foundEnum {
 			// Each one has several values:
-			for _, enumValue := range enumDescriptor.Value {
+			for _, enumValue := range enumDescriptorFound.Value {
 
 				// Put the ENUM values into the JSONSchema list of allowed ENUM values:
 				jsonSchemaType.Enum = append(jsonSchemaType.Enum, enumValue.Name)
@@ -288,12 +446,39 @@ func convertField(curPkg *ProtoPackage, desc *descriptor.FieldDescriptorProto, m
 			}
 		}
 
+		externalRef, isExternal := state.externalRef(desc.GetTypeName())
 		if !foundEnum {
 			logWithLevel(LOG_WARN, "could not find matching enum for field %s with type %s", *desc.Name, *desc.TypeName)
+		} else if isExternal || state.useDefinitions {
+			if isExternal {
+				// Reference the file generated for the enum instead:
+				jsonSchemaType = &jsonschema.Type{Ref: externalRef}
+			} else {
+				// Move the enum into the definitions, and reference it instead:
+				definitionName := strings.TrimPrefix(desc.GetTypeName(), ".")
+				if _, ok := state.definitions[definitionName]; !ok {
+					enumJSONSchemaType, err := convertEnumType(enumDescriptorFound)
+					if err != nil {
+						return nil, err
+					}
+					enumJSONSchemaType.Version = ""
+					state.definitions[definitionName] = &enumJSONSchemaType
+				}
+				jsonSchemaType = &jsonschema.Type{Ref: definitionRef(definitionName)}
+			}
+
+			// Optionally allow NULL values:
+			if allowNull && allowOneOf {
+				jsonSchemaType.OneOf = []*jsonschema.Type{
+					{Type: gojsonschema.TYPE_NULL},
+					{Ref: jsonSchemaType.Ref},
+				}
+				jsonSchemaType.Ref = ""
+			}
 		}
 
 	case descriptor.FieldDescriptorProto_TYPE_BOOL:
-		if allowNullValues && allowOneOf {
+		if allowNull && allowOneOf {
 			jsonSchemaType.OneOf = []*jsonschema.Type{
 				{Type: gojsonschema.TYPE_NULL},
 				{Type: gojsonschema.TYPE_BOOLEAN},
@@ -304,21 +489,18 @@ func convertField(curPkg *ProtoPackage, desc *descriptor.FieldDescriptorProto, m
 
 	case descriptor.FieldDescriptorProto_TYPE_GROUP,
 		descriptor.FieldDescriptorProto_TYPE_MESSAGE:
-		switch desc.GetTypeName() {
-		case ".google.protobuf.Timestamp":
-			jsonSchemaType.Type = gojsonschema.TYPE_STRING
-			jsonSchemaType.Format = "date-time"
-		default:
-			jsonSchemaType.Type = gojsonschema.TYPE_OBJECT
-			if disallowAdditionalProperties {
-				jsonSchemaType.AdditionalProperties = []byte("false")
-			} else {
-				if desc.GetLabel() == descriptor.FieldDescriptorProto_LABEL_OPTIONAL {
-					jsonSchemaType.AdditionalProperties = []byte("true")
-				}
-				if desc.GetLabel() == descriptor.FieldDescriptorProto_LABEL_REQUIRED {
-					jsonSchemaType.AdditionalProperties = []byte("false")
-				}
+		// Maps are repeated "XxxEntry" messages on the wire, but protojson renders them as objects:
+		if recordType, ok := curPkg.lookupType(desc.GetTypeName()); ok && recordType.GetOptions().GetMapEntry() {
+			return convertMapField(curPkg, recordType, msg, state)
+		}
+
+		jsonSchemaType.Type = gojsonschema.TYPE_OBJECT
+		if disallowAdditionalProperties {
+			jsonSchemaType.AdditionalProperties = jsonschema.Bool(false)
+		} else {
+			// A "required" label says nothing about the contents of the message, just that it has to be there:
+			if desc.GetLabel() != descriptor.FieldDescriptorProto_LABEL_REPEATED {
+				jsonSchemaType.AdditionalProperties = jsonschema.Bool(true)
 			}
 		}
 
@@ -340,19 +522,15 @@ func convertField(curPkg *ProtoPackage, desc *descriptor.FieldDescriptorProto, m
 				jsonSchemaType.Items.Type = jsonSchemaType.Type
 			}
 		} else {
-			jsonSchemaType.Items.Type = jsonSchemaType.Type
-			jsonSchemaType.Items.OneOf = jsonSchemaType.OneOf
+			// Everything else describes each of the items:
+			items := *jsonSchemaType
+			items.Properties, items.Items = nil, nil
+			jsonSchemaType = &jsonschema.Type{Items: &items}
 		}
 
-		if allowNullValues && allowOneOf {
-			jsonSchemaType.OneOf = []*jsonschema.Type{
-				{Type: gojsonschema.TYPE_NULL},
-				{Type: gojsonschema.TYPE_ARRAY},
-			}
-		} else {
-			jsonSchemaType.Type = gojsonschema.TYPE_ARRAY
-			jsonSchemaType.OneOf = []*jsonschema.Type{}
-		}
+		// Arrays have no presence, so they are never NULL:
+		jsonSchemaType.Type = gojsonschema.TYPE_ARRAY
+		jsonSchemaType.OneOf = []*jsonschema.Type{}
 
 		return jsonSchemaType, nil
 	}
@@ -365,49 +543,199 @@ func convertField(curPkg *ProtoPackage, desc *descriptor.FieldDescriptorProto, m
 			return nil, fmt.Errorf("no such message type named %s", desc.GetTypeName())
 		}
 
-		// C. Locklear -- I think we need to add all the enums from msg into recordType here
-		if len(recordType.EnumType) == 0 {
-			for _, d := range msg.EnumType {
-				recordType.EnumType = append(recordType.EnumType, d)
+		// Messages end up in the definitions (and get referenced) when asked to, or when they are recursive:
+		definitionName := strings.TrimPrefix(desc.GetTypeName(), ".")
+		recursedJSONSchemaType := jsonschema.Type{}
+		ref := ""
+		if desc.GetTypeName() == state.rootName {
+			// Recursing back to the root message refers to the whole schema:
+			ref = "#"
+		} else if externalRef, ok := state.externalRef(desc.GetTypeName()); ok {
+			// Messages from other proto files can refer to the files generated for them:
+			ref = externalRef
+		} else if _, ok := state.definitions[definitionName]; ok {
+			ref = definitionRef(definitionName)
+		} else if state.converting[desc.GetTypeName()] {
+			// This message is already being converted further up, so it will be moved into the definitions:
+			logWithLevel(LOG_DEBUG, "Found recursive message type %s", desc.GetTypeName())
+			state.definitions[definitionName] = &jsonschema.Type{}
+			ref = definitionRef(definitionName)
+		} else {
+			// Recurse:
+			state.converting[desc.GetTypeName()] = true
+			convertedJSONSchemaType, err := convertMessageType(curPkg, recordType, state)
+			delete(state.converting, desc.GetTypeName())
+			if err != nil {
+				return nil, err
+			}
+			if _, recursive := state.definitions[definitionName]; recursive || state.useDefinitions {
+				convertedJSONSchemaType.Version = ""
+				state.definitions[definitionName] = &convertedJSONSchemaType
+				ref = definitionRef(definitionName)
+			} else {
+				recursedJSONSchemaType = convertedJSONSchemaType
 			}
-		}
-		// Recurse:
-		recursedJSONSchemaType, err := convertMessageType(curPkg, recordType)
-		if err != nil {
-			return nil, err
 		}
 
 		// The result is stored differently for arrays of objects (they become "items"):
-		if desc.GetLabel() == descriptor.FieldDescriptorProto_LABEL_REPEATED {
+		if ref != "" {
+			if desc.GetLabel() == descriptor.FieldDescriptorProto_LABEL_REPEATED {
+				jsonSchemaType = &jsonschema.Type{
+					Items: &jsonschema.Type{Ref: ref},
+					Type:  gojsonschema.TYPE_ARRAY,
+				}
+			} else {
+				jsonSchemaType = &jsonschema.Type{Ref: ref}
+			}
+		} else if desc.GetLabel() == descriptor.FieldDescriptorProto_LABEL_REPEATED {
 			jsonSchemaType.Items = &recursedJSONSchemaType
 			jsonSchemaType.Type = gojsonschema.TYPE_ARRAY
 		} else {
 			// Nested objects are more straight-forward:
 			jsonSchemaType.Properties = recursedJSONSchemaType.Properties
+			jsonSchemaType.PropertyOrder = recursedJSONSchemaType.PropertyOrder
+			jsonSchemaType.AdditionalProperties = recursedJSONSchemaType.AdditionalProperties
+			jsonSchemaType.AllOf = objectSchema(&recursedJSONSchemaType).AllOf
+			jsonSchemaType.Required = recursedJSONSchemaType.Required
 		}
 
 		// Optionally allow NULL values:
-		if allowNullValues && allowOneOf {
-			jsonSchemaType.OneOf = []*jsonschema.Type{
-				{Type: gojsonschema.TYPE_NULL},
-				{Type: jsonSchemaType.Type},
+		if allowNull && allowOneOf {
+			if jsonSchemaType.Ref != "" {
+				jsonSchemaType.OneOf = []*jsonschema.Type{
+					{Type: gojsonschema.TYPE_NULL},
+					{Ref: jsonSchemaType.Ref},
+				}
+				jsonSchemaType.Ref = ""
+			} else {
+				jsonSchemaType.OneOf = []*jsonschema.Type{
+					{Type: gojsonschema.TYPE_NULL},
+					{Type: jsonSchemaType.Type, AllOf: jsonSchemaType.AllOf},
+				}
+				jsonSchemaType.Type, jsonSchemaType.AllOf = "", nil
 			}
-			jsonSchemaType.Type = ""
 		}
 	}
 
 	return jsonSchemaType, nil
 }
 
+// Converts a proto "map" field (a repeated synthetic "MapEntry" message) into a JSON-Schema object:
+func convertMapField(curPkg *ProtoPackage, entry *descriptor.DescriptorProto, msg *descriptor.DescriptorProto, state *conversionState) (*jsonschema.Type, error) {
+	// Map entries always have a "key" (1) and a "value" (2) field:
+	var keyDesc, valueDesc *descriptor.FieldDescriptorProto
+	for _, fieldDesc := range entry.GetField() {
+		switch fieldDesc.GetNumber() {
+		case 1:
+			keyDesc = fieldDesc
+		case 2:
+			valueDesc = fieldDesc
+		}
+	}
+	if keyDesc == nil || valueDesc == nil {
+		return nil, fmt.Errorf("map entry %s has no key or value field", entry.GetName())
+	}
+
+	// Values are converted like any other field (of the entry, so they are never NULL):
+	valueJSONSchemaType, err := convertField(curPkg, valueDesc, entry, state)
+	if err != nil {
+		return nil, err
+	}
+
+	// Prepare a new jsonschema.Type for our eventual return value (maps have no presence, so they are never NULL):
+	jsonSchemaType := &jsonschema.Type{
+		Type: gojsonschema.TYPE_OBJECT,
+	}
+
+	// JSON object keys are always strings, so non-string keys are constrained with a pattern
+	// (draft-04 has no "propertyNames", so that gets written out as "patternProperties" instead):
+	keyPattern, err := mapKeyPattern(keyDesc)
+	if err != nil {
+		return nil, err
+	}
+	jsonSchemaType.AdditionalProperties = valueJSONSchemaType
+	if keyPattern != "" {
+		jsonSchemaType.PropertyNames = &jsonschema.Type{Pattern: keyPattern}
+	}
+
+	return jsonSchemaType, nil
+}
+
+// Returns the pattern that the JSON representation of a map key must match (empty for string keys):
+func mapKeyPattern(keyDesc *descriptor.FieldDescriptorProto) (string, error) {
+	switch keyDesc.GetType() {
+	case descriptor.FieldDescriptorProto_TYPE_STRING:
+		return "", nil
+	case descriptor.FieldDescriptorProto_TYPE_INT32,
+		descriptor.FieldDescriptorProto_TYPE_SFIXED32,
+		descriptor.FieldDescriptorProto_TYPE_SINT32,
+		descriptor.FieldDescriptorProto_TYPE_INT64,
+		descriptor.FieldDescriptorProto_TYPE_SFIXED64,
+		descriptor.FieldDescriptorProto_TYPE_SINT64:
+		return "^-?[0-9]+$", nil
+	case descriptor.FieldDescriptorProto_TYPE_UINT32,
+		descriptor.FieldDescriptorProto_TYPE_FIXED32,
+		descriptor.FieldDescriptorProto_TYPE_UINT64,
+		descriptor.FieldDescriptorProto_TYPE_FIXED64:
+		return "^[0-9]+$", nil
+	case descriptor.FieldDescriptorProto_TYPE_BOOL:
+		return "^(true|false)$", nil
+	default:
+		return "", fmt.Errorf("unsupported map key type: %s", keyDesc.GetType().String())
+	}
+}
+
+// The range of values of each proto integer type:
+var integerRanges = map[descriptor.FieldDescriptorProto_Type]struct {
+	minimum, maximum json.Number
+	pattern          string // Pattern for the string form of 64-bit integers
+}{
+	descriptor.FieldDescriptorProto_TYPE_INT32:    {jsonschema.Int(math.MinInt32), jsonschema.Int(math.MaxInt32), ""},
+	descriptor.FieldDescriptorProto_TYPE_SINT32:   {jsonschema.Int(math.MinInt32), jsonschema.Int(math.MaxInt32), ""},
+	descriptor.FieldDescriptorProto_TYPE_SFIXED32: {jsonschema.Int(math.MinInt32), jsonschema.Int(math.MaxInt32), ""},
+	descriptor.FieldDescriptorProto_TYPE_UINT32:   {jsonschema.Uint(0), jsonschema.Uint(math.MaxUint32), ""},
+	descriptor.FieldDescriptorProto_TYPE_FIXED32:  {jsonschema.Uint(0), jsonschema.Uint(math.MaxUint32), ""},
+	descriptor.FieldDescriptorProto_TYPE_INT64:    {jsonschema.Int(math.MinInt64), jsonschema.Int(math.MaxInt64), "^-?[0-9]+$"},
+	descriptor.FieldDescriptorProto_TYPE_SINT64:   {jsonschema.Int(math.MinInt64), jsonschema.Int(math.MaxInt64), "^-?[0-9]+$"},
+	descriptor.FieldDescriptorProto_TYPE_SFIXED64: {jsonschema.Int(math.MinInt64), jsonschema.Int(math.MaxInt64), "^-?[0-9]+$"},
+	descriptor.FieldDescriptorProto_TYPE_UINT64:   {jsonschema.Uint(0), jsonschema.Uint(math.MaxUint64), "^[0-9]+$"},
+	descriptor.FieldDescriptorProto_TYPE_FIXED64:  {jsonschema.Uint(0), jsonschema.Uint(math.MaxUint64), "^[0-9]+$"},
+}
+
+// Constrains an integer (or the string form of a 64-bit integer, including those in "oneOf") to the range of
+// a proto integer type:
+func applyIntegerRange(jsonSchemaType *jsonschema.Type, fieldType descriptor.FieldDescriptorProto_Type) {
+	integerRange := integerRanges[fieldType]
+	switch jsonSchemaType.Type {
+	case gojsonschema.TYPE_INTEGER:
+		jsonSchemaType.Minimum, jsonSchemaType.Maximum = integerRange.minimum, integerRange.maximum
+	case gojsonschema.TYPE_STRING:
+		jsonSchemaType.Pattern = integerRange.pattern
+	}
+	for _, oneOfJSONSchemaType := range jsonSchemaType.OneOf {
+		applyIntegerRange(oneOfJSONSchemaType, fieldType)
+	}
+}
+
+// Matches the base64 that protojson accepts for bytes: the standard or the URL-safe alphabet (but not a mixture
+// of both), with or without padding:
+const base64Pattern = `^((([A-Za-z0-9+/]{4})*([A-Za-z0-9+/]{2}(==)?|[A-Za-z0-9+/]{3}=?)?)|(([A-Za-z0-9_-]{4})*([A-Za-z0-9_-]{2}(==)?|[A-Za-z0-9_-]{3}=?)?))$`
+
+// Constrains a string to base64 encoded binary data:
+func applyBase64Encoding(jsonSchemaType *jsonschema.Type) {
+	jsonSchemaType.Pattern = base64Pattern
+	jsonSchemaType.ContentEncoding = "base64"
+}
+
 // Converts a proto "MESSAGE" into a JSON-Schema:
-func convertMessageType(curPkg *ProtoPackage, msg *descriptor.DescriptorProto) (jsonschema.Type, error) {
+func convertMessageType(curPkg *ProtoPackage, msg *descriptor.DescriptorProto, state *conversionState) (jsonschema.Type, error) {
 	// Helpers for this inverse logic shit
 	allowOneOf := !disallowOneOf
 
 	// Prepare a new jsonschema:
 	jsonSchemaType := jsonschema.Type{
 		Properties: make(map[string]*jsonschema.Type),
-		Version:    jsonschema.Version,
+		Version:    schemaDraft.Version(),
 	}
 
 	// Optionally allow NULL values:
@@ -421,24 +749,133 @@ func convertMessageType(curPkg *ProtoPackage, msg *descriptor.DescriptorProto) (
 	}
 
 	// disallowAdditionalProperties will prevent validation where extra fields are found (outside of the schema):
-	if disallowAdditionalProperties {
-		jsonSchemaType.AdditionalProperties = []byte("false")
+	disallowAdditional, err := disallowAdditionalPropertiesFor(msg)
+	if err != nil {
+		return jsonSchemaType, err
+	}
+	if disallowAdditional {
+		jsonSchemaType.AdditionalProperties = jsonschema.Bool(false)
 	} else {
-		jsonSchemaType.AdditionalProperties = []byte("true")
+		jsonSchemaType.AdditionalProperties = jsonschema.Bool(true)
+	}
+
+	// Describe the message with its comments (or options):
+	applyComments(&jsonSchemaType, msg)
+	if err := applyMessageOptions(&jsonSchemaType, msg); err != nil {
+		return jsonSchemaType, err
 	}
 
 	logWithLevel(LOG_DEBUG, "Converting message: %s", proto.MarshalTextString(msg))
-	for _, fieldDesc := range msg.GetField() {
-		recursedJSONSchemaType, err := convertField(curPkg, fieldDesc, msg)
+	for _, fieldDesc := range orderedFields(msg) {
+		recursedJSONSchemaType, err := convertField(curPkg, fieldDesc, msg, state)
 		if err != nil {
 			logWithLevel(LOG_ERROR, "Failed to convert field %s in %s: %v", fieldDesc.GetName(), msg.GetName(), err)
 			return jsonSchemaType, err
 		}
-		jsonSchemaType.Properties[fieldDesc.GetJsonName()] = recursedJSONSchemaType
+		applyComments(recursedJSONSchemaType, fieldDesc)
+		required := isRequiredField(fieldDesc, msg)
+		if usePGVRules {
+			pgvRequired, err := applyPGVRules(recursedJSONSchemaType, fieldDesc)
+			if err != nil {
+				return jsonSchemaType, err
+			}
+			required = required || pgvRequired
+		}
+		if err := applyFieldOptions(recursedJSONSchemaType, fieldDesc); err != nil {
+			return jsonSchemaType, err
+		}
+		names := propertyNamesFor(fieldDesc)
+		for _, name := range names {
+			jsonSchemaType.Properties[name] = recursedJSONSchemaType
+			if propertyOrder != propertyOrderAlphabetical {
+				jsonSchemaType.PropertyOrder = append(jsonSchemaType.PropertyOrder, name)
+			}
+		}
+		objectJSONSchemaType := objectSchema(&jsonSchemaType)
+		if len(names) > 1 {
+			// Fields which can have either name can't have both of them at once:
+			objectJSONSchemaType.AllOf = append(objectJSONSchemaType.AllOf, &jsonschema.Type{
+				Not: &jsonschema.Type{Required: names},
+			})
+		}
+		if required {
+			if len(names) > 1 {
+				objectJSONSchemaType.AllOf = append(objectJSONSchemaType.AllOf, requiredProperty(names))
+			} else {
+				jsonSchemaType.Required = append(jsonSchemaType.Required, names[0])
+			}
+		}
 	}
+
+	// Proto "oneof" groups need their own constraints (which rely on "oneOf"):
+	if allowOneOf {
+		for oneOfIndex, oneOfDecl := range msg.GetOneofDecl() {
+			// proto3 "optional" fields each get a oneof of their own, which doesn't need constraining:
+			if isSyntheticOneOf(msg, oneOfIndex) {
+				continue
+			}
+
+			var oneOfPropertyNames [][]string
+			for _, fieldDesc := range orderedFields(msg) {
+				if fieldDesc.OneofIndex != nil && int(fieldDesc.GetOneofIndex()) == oneOfIndex {
+					oneOfPropertyNames = append(oneOfPropertyNames, propertyNamesFor(fieldDesc))
+				}
+			}
+			logWithLevel(LOG_DEBUG, "Constraining oneof %s in %s to one of %v", oneOfDecl.GetName(), msg.GetName(), oneOfPropertyNames)
+			objectJSONSchemaType := objectSchema(&jsonSchemaType)
+			objectJSONSchemaType.AllOf = append(objectJSONSchemaType.AllOf, convertOneOfDecl(oneOfPropertyNames))
+		}
+	}
+
 	return jsonSchemaType, nil
 }
 
+// Whether a field has to be present in JSON. This is the case for proto2 "required" fields, and (with
+// requireProto3Scalars) for proto3 scalars and enums which don't track presence (as strict producers always send them):
+func isRequiredField(desc *descriptor.FieldDescriptorProto, msg *descriptor.DescriptorProto) bool {
+	if desc.GetLabel() == descriptor.FieldDescriptorProto_LABEL_REQUIRED {
+		return true
+	}
+
+	return requireProto3Scalars &&
+		messageSyntaxes[msg] == "proto3" &&
+		desc.GetLabel() == descriptor.FieldDescriptorProto_LABEL_OPTIONAL &&
+		!hasExplicitPresence(desc, msg)
+}
+
+// Returns the part of a message's JSON-Schema which only applies to objects (the "object" branch when it can be NULL).
+// Constraints on which properties are present go there, as NULL has no properties (so it would match every "required"):
+func objectSchema(jsonSchemaType *jsonschema.Type) *jsonschema.Type {
+	for _, oneOfJSONSchemaType := range jsonSchemaType.OneOf {
+		if oneOfJSONSchemaType.Type == gojsonschema.TYPE_OBJECT {
+			return oneOfJSONSchemaType
+		}
+	}
+	return jsonSchemaType
+}
+
+// Converts the properties of a proto "oneof" (given as the names each of them can have) into a constraint allowing
+// at most one of them to be present (or exactly one of them with enforceOneOf):
+func convertOneOfDecl(propertyNames [][]string) *jsonschema.Type {
+	var requiredTypes []*jsonschema.Type
+	for _, names := range propertyNames {
+		requiredTypes = append(requiredTypes, requiredProperty(names))
+	}
+
+	jsonSchemaType := &jsonschema.Type{
+		OneOf: append([]*jsonschema.Type{}, requiredTypes...),
+	}
+
+	// Unless enforced, it is also fine for none of them to be present:
+	if !enforceOneOf {
+		jsonSchemaType.OneOf = append(jsonSchemaType.OneOf, &jsonschema.Type{
+			Not: &jsonschema.Type{AnyOf: requiredTypes},
+		})
+	}
+
+	return jsonSchemaType
+}
+
 // Converts a proto "ENUM" into a JSON-Schema:
 func convertEnumType(enum *descriptor.EnumDescriptorProto) (jsonschema.Type, error) {
 	// Helpers for this inverse logic shit
@@ -447,7 +884,7 @@ func convertEnumType(enum *descriptor.EnumDescriptorProto) (jsonschema.Type, err
 
 	// Prepare a new jsonschema.Type for our eventual return value:
 	jsonSchemaType := jsonschema.Type{
-		Version: jsonschema.Version,
+		Version: schemaDraft.Version(),
 	}
 
 	if allowEnumOneOf && allowOneOf {
@@ -467,6 +904,12 @@ func convertEnumType(enum *descriptor.EnumDescriptorProto) (jsonschema.Type, err
 		}
 	}
 
+	// Describe the enum (and its values) with its comments (or options):
+	applyEnumComments(&jsonSchemaType, enum)
+	if err := applyEnumOptions(&jsonSchemaType, enum); err != nil {
+		return jsonSchemaType, err
+	}
+
 	return jsonSchemaType, nil
 }
 
@@ -488,64 +931,45 @@ func convertFile(file *descriptor.FileDescriptorProto) ([]*plugin.CodeGeneratorR
 	}
 
 	// Generate standalone ENUMs:
-	if len(file.GetMessageType()) == 0 {
-		for _, enum := range file.GetEnumType() {
-			jsonSchemaFileName := fmt.Sprintf("%s.jsonschema", enum.GetName())
-			logWithLevel(LOG_INFO, "Generating JSON-schema for stand-alone ENUM (%v) in file [%v] => %v", enum.GetName(), protoFileName, jsonSchemaFileName)
-			enumJsonSchema, err := convertEnumType(enum)
-			if err != nil {
-				logWithLevel(LOG_ERROR, "Failed to convert %s: %v", protoFileName, err)
-				return nil, err
-			} else {
-				// Marshal the JSON-Schema into JSON:
-				jsonSchemaJSON, err := json.MarshalIndent(enumJsonSchema, "", "    ")
-				if err != nil {
-					logWithLevel(LOG_ERROR, "Failed to encode jsonSchema: %v", err)
-					return nil, err
-				} else {
-					// Add a response:
-					resFile := &plugin.CodeGeneratorResponse_File{
-						Name:    proto.String(jsonSchemaFileName),
-						Content: proto.String(string(jsonSchemaJSON)),
-					}
-					response = append(response, resFile)
-				}
-			}
+	for _, enum := range file.GetEnumType() {
+		jsonSchemaFileName := outputFileName(file, enum.GetName())
+		logWithLevel(LOG_INFO, "Generating JSON-schema for stand-alone ENUM (%v) in file [%v] => %v", enum.GetName(), protoFileName, jsonSchemaFileName)
+		enumJsonSchema, err := convertEnumType(enum)
+		if err != nil {
+			logWithLevel(LOG_ERROR, "Failed to convert %s: %v", protoFileName, err)
+			return nil, err
 		}
-	} else {
-		// Otherwise process MESSAGES (packages):
+		resFile, err := newResponseFile(jsonSchemaFileName, &enumJsonSchema)
+		if err != nil {
+			return nil, err
+		}
+		response = append(response, resFile)
+	}
+
+	// Then process MESSAGES (packages):
+	if len(file.GetMessageType()) > 0 {
 		pkg, ok := globalPkg.relativelyLookupPackage(file.GetPackage())
 		if !ok {
 			return nil, fmt.Errorf("no such package found: %s", file.GetPackage())
 		}
 		for _, msg := range file.GetMessageType() {
-			jsonSchemaFileName := fmt.Sprintf("%s.jsonschema", msg.GetName())
+			jsonSchemaFileName := outputFileName(file, msg.GetName())
 			logWithLevel(LOG_INFO, "Generating JSON-schema for MESSAGE (%v) in file [%v] => %v", msg.GetName(), protoFileName, jsonSchemaFileName)
-			// C. Locklear -- Let's send any ENUMs we know about into this msg so that
-			// we can find them when we build our JSON schema.  This will solve the scenario
-			// that arises when an enum is used in message, defined outside the message, but
-			// in the same file.
-			for _, v := range file.EnumType {
-				msg.EnumType = append(msg.EnumType, v)
-			}
-			messageJSONSchema, err := convertMessageType(pkg, msg)
+			resFile, err := convertRootMessage(pkg, file, msg.GetName(), msg)
 			if err != nil {
 				logWithLevel(LOG_ERROR, "Failed to convert %s: %v", protoFileName, err)
 				return nil, err
-			} else {
-				// Marshal the JSON-Schema into JSON:
-				jsonSchemaJSON, err := json.MarshalIndent(messageJSONSchema, "", "    ")
+			}
+			response = append(response, resFile)
+
+			// Optionally give nested messages and enums their own files too:
+			if generateNestedTypes {
+				nestedResponse, err := convertNestedTypes(pkg, file, msg.GetName(), msg)
 				if err != nil {
-					logWithLevel(LOG_ERROR, "Failed to encode jsonSchema: %v", err)
+					logWithLevel(LOG_ERROR, "Failed to convert %s: %v", protoFileName, err)
 					return nil, err
-				} else {
-					// Add a response:
-					resFile := &plugin.CodeGeneratorResponse_File{
-						Name:    proto.String(jsonSchemaFileName),
-						Content: proto.String(string(jsonSchemaJSON)),
-					}
-					response = append(response, resFile)
 				}
+				response = append(response, nestedResponse...)
 			}
 		}
 	}
@@ -553,43 +977,175 @@ func convertFile(file *descriptor.FileDescriptorProto) ([]*plugin.CodeGeneratorR
 	return response, nil
 }
 
-func convert(req *plugin.CodeGeneratorRequest) (*plugin.CodeGeneratorResponse, error) {
-	generateTargets := make(map[string]bool)
-	for _, file := range req.GetFileToGenerate() {
-		generateTargets[file] = true
+// Converts a message into a complete JSON-Schema file of its own (named after the message):
+func convertRootMessage(pkg *ProtoPackage, file *descriptor.FileDescriptorProto, msgName string, msg *descriptor.DescriptorProto) (*plugin.CodeGeneratorResponse_File, error) {
+	state := newConversionState(file, msgName)
+	messageJSONSchema, err := convertMessageType(pkg, msg, state)
+	if err != nil {
+		return nil, err
 	}
+	if len(state.definitions) > 0 {
+		messageJSONSchema.Definitions = state.definitions
+	}
+	return newResponseFile(state.outputFileName, &messageJSONSchema)
+}
+
+// Generates files for the messages and enums nested inside a message (named by their path, eg "Outer.Inner"):
+func convertNestedTypes(pkg *ProtoPackage, file *descriptor.FileDescriptorProto, msgName string, msg *descriptor.DescriptorProto) ([]*plugin.CodeGeneratorResponse_File, error) {
+	response := []*plugin.CodeGeneratorResponse_File{}
+
+	for _, nestedMsg := range msg.GetNestedType() {
+		// Map entries are an implementation detail of map fields:
+		if nestedMsg.GetOptions().GetMapEntry() {
+			continue
+		}
+		nestedMsgName := msgName + "." + nestedMsg.GetName()
+		logWithLevel(LOG_INFO, "Generating JSON-schema for nested MESSAGE (%v) in file [%v]", nestedMsgName, file.GetName())
+		resFile, err := convertRootMessage(pkg, file, nestedMsgName, nestedMsg)
+		if err != nil {
+			return nil, err
+		}
+		response = append(response, resFile)
+
+		nestedResponse, err := convertNestedTypes(pkg, file, nestedMsgName, nestedMsg)
+		if err != nil {
+			return nil, err
+		}
+		response = append(response, nestedResponse...)
+	}
+
+	for _, enum := range msg.GetEnumType() {
+		enumName := msgName + "." + enum.GetName()
+		logWithLevel(LOG_INFO, "Generating JSON-schema for nested ENUM (%v) in file [%v]", enumName, file.GetName())
+		enumJSONSchema, err := convertEnumType(enum)
+		if err != nil {
+			return nil, err
+		}
+		resFile, err := newResponseFile(outputFileName(file, enumName), &enumJSONSchema)
+		if err != nil {
+			return nil, err
+		}
+		response = append(response, resFile)
+	}
+
+	return response, nil
+}
+
+// Marshals a JSON-Schema into a file for the response:
+func newResponseFile(jsonSchemaFileName string, jsonSchemaType *jsonschema.Type) (*plugin.CodeGeneratorResponse_File, error) {
+	if baseURI != "" {
+		jsonSchemaType.ID = schemaURI(jsonSchemaFileName)
+	}
+	jsonSchemaJSON, err := marshalJSONSchema(jsonSchemaType)
+	if err != nil {
+		logWithLevel(LOG_ERROR, "Failed to encode jsonSchema: %v", err)
+		return nil, err
+	}
+	return &plugin.CodeGeneratorResponse_File{
+		Name:    proto.String(jsonSchemaFileName),
+		Content: proto.String(string(jsonSchemaJSON)),
+	}, nil
+}
+
+// Converts the files of a request (which is only ever read, never modified):
+func convert(req *plugin.CodeGeneratorRequest) (*plugin.CodeGeneratorResponse, error) {
+	protoFiles := make(map[string]*descriptor.FileDescriptorProto)
 
 	res := &plugin.CodeGeneratorResponse{}
-	enumDescriptors := make([]*descriptor.EnumDescriptorProto, 0)
+	setSupportedFeatures(res)
 	for _, file := range req.GetProtoFile() {
+		protoFiles[file.GetName()] = file
 		for _, msg := range file.GetMessageType() {
 			logWithLevel(LOG_DEBUG, "Loading a message type %s from package %s", msg.GetName(), file.GetPackage())
 			registerType(file.Package, msg)
 		}
-		// Gather all the enum descriptors referenced across all files.
-		// We're going to inject them into our converter to better improve
-		// the chances that external enums are properly converted in our
-		// new JSON schemas.
-		for _, d := range file.EnumType {
-			enumDescriptors = append(enumDescriptors, d)
+		for _, enum := range file.GetEnumType() {
+			logWithLevel(LOG_DEBUG, "Loading an enum type %s from package %s", enum.GetName(), file.GetPackage())
+			registerEnum(file.Package, enum)
+		}
+		registerDeclarations(file)
+		registerSyntax(file.GetSyntax(), file.GetMessageType())
+		registerComments(file)
+		fileOptions, err := getFileOptions(file)
+		if err != nil {
+			res.Error = proto.String(err.Error())
+			return res, err
 		}
+		registerFileOptions(fileOptions, file.GetMessageType())
 	}
-	for _, file := range req.GetProtoFile() {
-		if _, ok := generateTargets[file.GetName()]; ok {
-			logWithLevel(LOG_DEBUG, "Converting file (%v)", file.GetName())
-			// Swapparoo
-			file.EnumType = enumDescriptors
-			converted, err := convertFile(file)
-			if err != nil {
-				res.Error = proto.String(fmt.Sprintf("Failed to convert %s: %v", file.GetName(), err))
+	// Absolute references are made from the base URI:
+	if externalRefs == externalRefsAbsolute && baseURI == "" {
+		err := fmt.Errorf("external_refs=absolute needs a base_uri")
+		res.Error = proto.String(err.Error())
+		return res, err
+	}
+
+	// Bundles copy in everything they refer to, so they can't reference other files:
+	if externalRefs != externalRefsNone && bundling != bundleNone {
+		err := fmt.Errorf("external_refs can't be used with bundle")
+		res.Error = proto.String(err.Error())
+		return res, err
+	}
+
+	// Files are converted in the order they were asked for (so the order of the descriptors makes no difference):
+	var filesToGenerate []*descriptor.FileDescriptorProto
+	for _, fileName := range req.GetFileToGenerate() {
+		file, ok := protoFiles[fileName]
+		if !ok {
+			err := fmt.Errorf("no descriptor found for file to generate %s", fileName)
+			res.Error = proto.String(err.Error())
+			return res, err
+		}
+		filesToGenerate = append(filesToGenerate, file)
+	}
+	registerGeneratedFiles(filesToGenerate)
+
+	// Optionally bundle them together:
+	generatedBy := make(map[string]string)
+	if bundling != bundleNone {
+		converted, sources, err := convertBundles(filesToGenerate)
+		if err != nil {
+			res.Error = proto.String(err.Error())
+			return res, err
+		}
+		for i, resFile := range converted {
+			if err := recordOutputFile(generatedBy, resFile, sources[i]); err != nil {
+				res.Error = proto.String(err.Error())
 				return res, err
 			}
-			res.File = append(res.File, converted...)
 		}
+		res.File = converted
+		return res, nil
+	}
+
+	for _, file := range filesToGenerate {
+		logWithLevel(LOG_DEBUG, "Converting file (%v)", file.GetName())
+		converted, err := convertFile(file)
+		if err != nil {
+			res.Error = proto.String(fmt.Sprintf("Failed to convert %s: %v", file.GetName(), err))
+			return res, err
+		}
+		for _, resFile := range converted {
+			if err := recordOutputFile(generatedBy, resFile, file.GetName()); err != nil {
+				res.Error = proto.String(err.Error())
+				return res, err
+			}
+		}
+		res.File = append(res.File, converted...)
 	}
 	return res, nil
 }
 
+// Records which proto file an output file was generated from. Two schemas written to the same file would overwrite
+// each other (eg messages with the same name in different packages), so that is an error:
+func recordOutputFile(generatedBy map[string]string, resFile *plugin.CodeGeneratorResponse_File, protoFileName string) error {
+	if otherProtoFileName, ok := generatedBy[resFile.GetName()]; ok {
+		return fmt.Errorf("%s would be generated from both %s and %s (use the paths or filename parameters to tell them apart)", resFile.GetName(), otherProtoFileName, protoFileName)
+	}
+	generatedBy[resFile.GetName()] = protoFileName
+	return nil
+}
+
 func convertFrom(rd io.Reader) (*plugin.CodeGeneratorResponse, error) {
 	logWithLevel(LOG_DEBUG, "Reading code generation request")
 	input, err := ioutil.ReadAll(rd)
@@ -605,17 +1161,34 @@ func convertFrom(rd io.Reader) (*plugin.CodeGeneratorResponse, error) {
 		return nil, err
 	}
 
-	commandLineParameter(req.GetParameter())
+	if err := commandLineParameter(req.GetParameter()); err != nil {
+		logWithLevel(LOG_ERROR, "Invalid parameters: %v", err)
+		return nil, err
+	}
 
 	logWithLevel(LOG_DEBUG, "Converting input")
 	return convert(req)
 }
 
-func commandLineParameter(parameters string) {
+func commandLineParameter(parameters string) error {
 	for _, parameter := range strings.Split(parameters, ",") {
+		// Some parameters have a value (eg "exclude_comment_prefix=@exclude"):
+		parameterValue := ""
+		if parameterParts := strings.SplitN(parameter, "=", 2); len(parameterParts) == 2 {
+			parameter, parameterValue = parameterParts[0], parameterParts[1]
+		}
+
 		switch parameter {
 		case "allow_null_values":
 			allowNullValues = true
+		case "base64_bytes":
+			base64Bytes = true
+		case "base_uri":
+			baseURI = parameterValue
+		case "bundle":
+			if err := bundling.Set(parameterValue); err != nil {
+				return err
+			}
 		case "debug":
 			debugLogging = true
 		case "disallow_enum_one_of":
@@ -630,8 +1203,55 @@ func commandLineParameter(parameters string) {
 			disallowAdditionalProperties = true
 		case "disallow_bigints_as_strings":
 			disallowBigIntsAsStrings = true
+		case "draft":
+			if err := schemaDraft.Set(parameterValue); err != nil {
+				return err
+			}
+		case "enforce_oneof":
+			enforceOneOf = true
+		case "exclude_comment_prefix":
+			excludeCommentPrefixes = append(excludeCommentPrefixes, parameterValue)
+		case "external_refs":
+			if err := externalRefs.Set(parameterValue); err != nil {
+				return err
+			}
+		case "filename":
+			if err := outputFileNameTemplate.Set(parameterValue); err != nil {
+				return err
+			}
+		case "float_format":
+			floatFormat = true
+		case "float_values":
+			if err := floatValues.Set(parameterValue); err != nil {
+				return err
+			}
+		case "format":
+			if err := schemaFormat.Set(parameterValue); err != nil {
+				return err
+			}
+		case "generate_nested_types":
+			generateNestedTypes = true
+		case "paths":
+			if err := outputPaths.Set(parameterValue); err != nil {
+				return err
+			}
+		case "property_order":
+			if err := propertyOrder.Set(parameterValue); err != nil {
+				return err
+			}
+		case "proto_names":
+			if err := propertyNames.Set(parameterValue); err != nil {
+				return err
+			}
+		case "require_proto3_scalars":
+			requireProto3Scalars = true
+		case "use_definitions":
+			useDefinitions = true
+		case "use_pgv_rules":
+			usePGVRules = true
 		}
 	}
+	return nil
 }
 
 func main() {
@@ -3,32 +3,55 @@ package main
 import (
 	"bytes"
 	"fmt"
+	"math/rand"
 	"os/exec"
 	"strings"
 	"testing"
 
+	"github.com/RedVentures/protoc-gen-jsonschema/internal/jsonschema"
 	"github.com/RedVentures/protoc-gen-jsonschema/testdata"
 	"github.com/golang/protobuf/proto"
 	"github.com/golang/protobuf/protoc-gen-go/descriptor"
 	plugin "github.com/golang/protobuf/protoc-gen-go/plugin"
 	log "github.com/sirupsen/logrus"
 	"github.com/stretchr/testify/assert"
+	"github.com/xeipuuv/gojsonschema"
 )
 
 var (
-	protocBinary         = "/bin/protoc"
-	sampleProtoDirectory = "testdata/proto"
-	sampleProtos         = make(map[string]SampleProto)
+	protocBinary          = "/bin/protoc"
+	sampleProtoDirectory  = "testdata/proto"
+	optionsProtoDirectory = "options"
+	sampleProtos          = make(map[string]SampleProto)
 )
 
 type SampleProto struct {
-	AllowNullValues    bool
-	DisallowEnumOneOf  bool
-	DisallowOneOf      bool
-	DisallowAdditional bool
-	ExpectedJsonSchema []string
-	FilesToGenerate    []string
-	ProtoFileName      string
+	AllowNullValues        bool
+	Base64Bytes            bool
+	BaseURI                string
+	Bundle                 bundleMode
+	DisallowEnumOneOf      bool
+	DisallowOneOf          bool
+	DisallowAdditional     bool
+	EnforceOneOf           bool
+	ExternalRefs           externalRefMode
+	FileNameTemplate       string
+	FloatFormat            bool
+	FloatValues            floatValueMode
+	GenerateNestedTypes    bool
+	Paths                  outputPathLayout
+	PropertyOrder          propertyOrdering
+	PropertyNames          propertyNaming
+	RequireProto3          bool
+	UseDefinitions         bool
+	UsePGVRules            bool
+	Draft                  jsonschema.Draft
+	Format                 outputFormat
+	ExcludeCommentPrefixes []string
+	ExpectedFileNames      []string
+	ExpectedJsonSchema     []string
+	FilesToGenerate        []string
+	ProtoFileName          string
 }
 
 func TestGenerateJsonSchema(t *testing.T) {
@@ -45,22 +68,244 @@ func TestGenerateJsonSchema(t *testing.T) {
 	testConvertSampleProtos(t, sampleProtos["ArrayOfMessages"])
 	testConvertSampleProtos(t, sampleProtos["ArrayOfObjects"])
 	testConvertSampleProtos(t, sampleProtos["ArrayOfPrimitives"])
+	testConvertSampleProtos(t, sampleProtos["BundleFile"])
+	testConvertSampleProtos(t, sampleProtos["BundlePackage"])
+	testConvertSampleProtos(t, sampleProtos["BundlePackageNoPackage"])
+	testConvertSampleProtos(t, sampleProtos["BundleRecursionDraft202012"])
+	testConvertSampleProtos(t, sampleProtos["Bytes"])
+	testConvertSampleProtos(t, sampleProtos["BytesDraft07"])
+	testConvertSampleProtos(t, sampleProtos["Comments"])
+	testConvertSampleProtos(t, sampleProtos["CommentsYAML"])
 	testConvertSampleProtos(t, sampleProtos["EnumCeption"])
+	testConvertSampleProtos(t, sampleProtos["EnumCollisions"])
+	testConvertSampleProtos(t, sampleProtos["EnumCeptionWithDefinitions"])
 	testConvertSampleProtos(t, sampleProtos["EnumWithNoOneOf"])
 	testConvertSampleProtos(t, sampleProtos["ExternalEnum"])
+	testConvertSampleProtos(t, sampleProtos["ExternalRefsAbsolute"])
+	testConvertSampleProtos(t, sampleProtos["ExternalRefsEnum"])
+	testConvertSampleProtos(t, sampleProtos["ExternalRefsNotGenerated"])
+	testConvertSampleProtos(t, sampleProtos["ExternalRefsRelative"])
+	testConvertSampleProtos(t, sampleProtos["FileNameTemplate"])
+	testConvertSampleProtos(t, sampleProtos["FileNameTemplateNestedTypes"])
+	testConvertSampleProtos(t, sampleProtos["FloatsProtojson"])
+	testConvertSampleProtos(t, sampleProtos["FloatsStrict"])
 	testConvertSampleProtos(t, sampleProtos["ImportedExternalEnum"])
 	testConvertSampleProtos(t, sampleProtos["ImportedEnumFromASiblingPackage"])
 	testConvertSampleProtos(t, sampleProtos["ImportedMessageFromASiblingPackageWithEnum"])
 	testConvertSampleProtos(t, sampleProtos["ImportedEnum"])
+	testConvertSampleProtos(t, sampleProtos["Integers"])
+	testConvertSampleProtos(t, sampleProtos["Maps"])
+	testConvertSampleProtos(t, sampleProtos["MapsAllowNullValues"])
+	testConvertSampleProtos(t, sampleProtos["MapsYAML"])
+	testConvertSampleProtos(t, sampleProtos["MapsDraft07"])
+	testConvertSampleProtos(t, sampleProtos["MixedEnumsAndMessages"])
 	testConvertSampleProtos(t, sampleProtos["NestedMessage"])
 	testConvertSampleProtos(t, sampleProtos["NestedMessageNoAdditionalProperties"])
 	testConvertSampleProtos(t, sampleProtos["NestedObject"])
+	testConvertSampleProtos(t, sampleProtos["NestedTypes"])
 	testConvertSampleProtos(t, sampleProtos["NoOneOf"])
+	testConvertSampleProtos(t, sampleProtos["NoPackage"])
+	testConvertSampleProtos(t, sampleProtos["OneOf"])
+	testConvertSampleProtos(t, sampleProtos["OneOfAllowNullValues"])
+	testConvertSampleProtos(t, sampleProtos["OneOfEnforced"])
+	testConvertSampleProtos(t, sampleProtos["Options"])
+	testConvertSampleProtos(t, sampleProtos["OptionsWithDefinitions"])
+	testConvertSampleProtos(t, sampleProtos["OptionsWithDefinitionsDraft201909"])
+	testConvertSampleProtos(t, sampleProtos["PathsFullyQualified"])
+	testConvertSampleProtos(t, sampleProtos["PathsPackage"])
+	testConvertSampleProtos(t, sampleProtos["PathsSourceRelative"])
 	testConvertSampleProtos(t, sampleProtos["PayloadMessage"])
+	testConvertSampleProtos(t, sampleProtos["PropertyOrder"])
+	testConvertSampleProtos(t, sampleProtos["PropertyOrderDeclaration"])
+	testConvertSampleProtos(t, sampleProtos["PropertyOrderFieldNumber"])
+	testConvertSampleProtos(t, sampleProtos["Proto2Required"])
+	testConvertSampleProtos(t, sampleProtos["ProtoNames"])
+	testConvertSampleProtos(t, sampleProtos["ProtoNamesBoth"])
+	testConvertSampleProtos(t, sampleProtos["ProtoNamesBothAllowNullValues"])
+	testConvertSampleProtos(t, sampleProtos["Proto3Optional"])
+	testConvertSampleProtos(t, sampleProtos["Proto3RequiredScalars"])
+	testConvertSampleProtos(t, sampleProtos["Recursion"])
+	testConvertSampleProtos(t, sampleProtos["RecursionDraft201909"])
 	testConvertSampleProtos(t, sampleProtos["SeveralEnums"])
 	testConvertSampleProtos(t, sampleProtos["SeveralMessages"])
 	testConvertSampleProtos(t, sampleProtos["ArrayOfEnums"])
 	testConvertSampleProtos(t, sampleProtos["Timestamp"])
+	testConvertSampleProtos(t, sampleProtos["ValidateRules"])
+	testConvertSampleProtos(t, sampleProtos["ValidateRulesDraft202012"])
+	testConvertSampleProtos(t, sampleProtos["WellKnown"])
+}
+
+func TestSupportedFeatures(t *testing.T) {
+	response, err := convert(&plugin.CodeGeneratorRequest{})
+	assert.NoError(t, err)
+
+	// The vendored plugin.CodeGeneratorResponse has no SupportedFeatures field, so decode it by hand:
+	buf := proto.NewBuffer(response.XXX_unrecognized)
+	key, err := buf.DecodeVarint()
+	assert.NoError(t, err)
+	assert.Equal(t, uint64(codeGeneratorResponseSupportedFeaturesFieldNumber<<3|proto.WireVarint), key)
+	supportedFeatures, err := buf.DecodeVarint()
+	assert.NoError(t, err)
+	assert.Equal(t, uint64(featureProto3Optional), supportedFeatures, "FEATURE_PROTO3_OPTIONAL should be supported")
+}
+
+func TestShuffledProtoFiles(t *testing.T) {
+	log.SetLevel(log.InfoLevel)
+	testForProtocBinary(t)
+	configureSampleProtos()
+
+	// The order of the descriptors in a request should make no difference to the output, and the request should be left alone:
+	random := rand.New(rand.NewSource(1))
+	for _, sampleProtoName := range []string{"BundlePackage", "EnumCeption", "EnumCollisions", "NestedTypes", "PropertyOrderFieldNumber", "Recursion"} {
+		sampleProto := sampleProtos[sampleProtoName]
+		codeGeneratorRequest := prepareSampleProto(t, sampleProto)
+		for i := 0; i < 10; i++ {
+			random.Shuffle(len(codeGeneratorRequest.ProtoFile), func(i, j int) {
+				codeGeneratorRequest.ProtoFile[i], codeGeneratorRequest.ProtoFile[j] = codeGeneratorRequest.ProtoFile[j], codeGeneratorRequest.ProtoFile[i]
+			})
+			originalRequest := proto.Clone(codeGeneratorRequest)
+
+			response, err := convert(codeGeneratorRequest)
+			assert.NoError(t, err, "Unable to convert shuffled sample proto (%v)", sampleProtoName)
+			assert.True(t, proto.Equal(originalRequest, codeGeneratorRequest), "Request was modified while converting sample proto (%v)", sampleProtoName)
+			if assert.Equal(t, len(sampleProto.ExpectedJsonSchema), len(response.File), "Incorrect number of JSON-Schema files returned for shuffled sample proto (%v)", sampleProtoName) {
+				for responseFileIndex, responseFile := range response.File {
+					assert.Equal(t, sampleProto.ExpectedJsonSchema[responseFileIndex], responseFile.GetContent(), "Incorrect JSON-Schema returned for shuffled sample proto (%v)", sampleProtoName)
+				}
+			}
+		}
+	}
+}
+
+func TestFileNameTemplates(t *testing.T) {
+	for template, expectedError := range map[string]string{
+		"{message}.{extension}":        "",
+		"{package|lower|kebab}/{file}": "",
+		"":                             "empty filename template",
+		"{message.jsonschema":          "unclosed placeholder in filename template \"{message.jsonschema\"",
+		"{name}.jsonschema":            "unknown placeholder \"name\" in filename template \"{name}.jsonschema\" (expected package, file, message, version or extension)",
+		"{message|camel}.jsonschema":   "unknown modifier \"camel\" in filename template \"{message|camel}.jsonschema\" (expected lower, upper, kebab or snake)",
+	} {
+		var parsedTemplate fileNameTemplate
+		err := parsedTemplate.Set(template)
+		if expectedError == "" {
+			assert.NoError(t, err, "Unable to parse filename template (%v)", template)
+		} else {
+			assert.EqualError(t, err, expectedError, "Incorrect error for filename template (%v)", template)
+		}
+	}
+
+	for value, expectedWords := range map[string]string{
+		"EventCollisions": "event-collisions",
+		"HTTPRequest_v2":  "http-request-v2",
+		"Outer.Inner":     "outer.inner",
+		"billing.v1":      "billing.v1",
+		"Int64Value":      "int64-value",
+	} {
+		assert.Equal(t, expectedWords, joinWords(value, "-"), "Incorrect words for (%v)", value)
+	}
+
+	// Separators next to an empty {version} are left out (along with empty directories):
+	unversionedFile := &descriptor.FileDescriptorProto{Name: proto.String("billing/Event.proto"), Package: proto.String("billing")}
+	versionedFile := &descriptor.FileDescriptorProto{Name: proto.String("billing/v1/Event.proto"), Package: proto.String("billing.v1")}
+	for template, expectedFileNames := range map[string][2]string{
+		"{message}-{version}.{extension}":        {"Event.jsonschema", "Event-v1.jsonschema"},
+		"{message}.{version}.{extension}":        {"Event.jsonschema", "Event.v1.jsonschema"},
+		"{version}_{message}.{extension}":        {"Event.jsonschema", "v1_Event.jsonschema"},
+		"{version}/{message}.{extension}":        {"Event.jsonschema", "v1/Event.jsonschema"},
+		"{file}/{version}-{message}.json":        {"Event/Event.json", "Event/v1-Event.json"},
+		"{message}-{version}-latest.{extension}": {"Event-latest.jsonschema", "Event-v1-latest.jsonschema"},
+	} {
+		var parsedTemplate fileNameTemplate
+		assert.NoError(t, parsedTemplate.Set(template), "Unable to parse filename template (%v)", template)
+		assert.Equal(t, expectedFileNames[0], parsedTemplate.execute(unversionedFile, "Event"), "Incorrect file name for filename template (%v)", template)
+		assert.Equal(t, expectedFileNames[1], parsedTemplate.execute(versionedFile, "Event"), "Incorrect file name for filename template (%v)", template)
+	}
+}
+
+func TestOutputFileCollisions(t *testing.T) {
+	log.SetLevel(log.InfoLevel)
+	testForProtocBinary(t)
+	configureSampleProtos()
+
+	// Messages with the same name in different packages can't both be written to the same file:
+	for sampleProtoName, fileNameTemplate := range map[string]string{"PathsPackage": "{message}.jsonschema", "FileNameTemplate": "{file|lower}.json", "BundleFile": ""} {
+		sampleProto := sampleProtos[sampleProtoName]
+		sampleProto.Paths = pathsFlat
+		sampleProto.FileNameTemplate = fileNameTemplate
+		response, err := convert(prepareSampleProto(t, sampleProto))
+		assert.Error(t, err, "Colliding output files were generated for sample proto (%v)", sampleProtoName)
+		assert.NotEmpty(t, response.GetError(), "No error was returned to protoc for sample proto (%v)", sampleProtoName)
+	}
+}
+
+func TestExternalRefsWithoutBaseURI(t *testing.T) {
+	log.SetLevel(log.InfoLevel)
+	testForProtocBinary(t)
+	configureSampleProtos()
+
+	// Absolute references can't be made without a base URI:
+	sampleProto := sampleProtos["ExternalRefsAbsolute"]
+	sampleProto.BaseURI = ""
+	response, err := convert(prepareSampleProto(t, sampleProto))
+	assert.EqualError(t, err, "external_refs=absolute needs a base_uri")
+	assert.Equal(t, "external_refs=absolute needs a base_uri", response.GetError())
+}
+
+func TestExternalRefsWithBundle(t *testing.T) {
+	log.SetLevel(log.InfoLevel)
+	testForProtocBinary(t)
+	configureSampleProtos()
+
+	// Bundles copy in everything they refer to, so asking for references as well is an error:
+	sampleProto := sampleProtos["BundleFile"]
+	sampleProto.ExternalRefs = externalRefsRelative
+	response, err := convert(prepareSampleProto(t, sampleProto))
+	assert.EqualError(t, err, "external_refs can't be used with bundle")
+	assert.Equal(t, "external_refs can't be used with bundle", response.GetError())
+}
+
+func TestNullMessages(t *testing.T) {
+	log.SetLevel(log.InfoLevel)
+	testForProtocBinary(t)
+	configureSampleProtos()
+
+	// Messages which can be NULL still constrain their properties when they aren't:
+	for sampleProtoName, instances := range map[string]map[string]bool{
+		"OneOfAllowNullValues": {
+			`null`:                                   true,
+			`{"name": "a", "json": true}`:            true,
+			`{"payload": null}`:                      true,
+			`{"payload": {"uuid": "a"}}`:             true,
+			`{"name": "a", "number": 1}`:             false,
+			`{"payload": {"uuid": "a", "index": 1}}`: false,
+		},
+		"ProtoNamesBothAllowNullValues": {
+			`null`:               true,
+			`{"firstName": "a"}`: true,
+			`{"first_name": "a", "emailAddress": "b"}`:                      true,
+			`{"firstName": "a", "first_name": "a"}`:                         false,
+			`{"lastName": "b"}`:                                             false,
+			`{"first_name": "a", "email_address": "b", "phoneNumber": "c"}`: false,
+		},
+		"Proto3Optional": {
+			`null`:                       true,
+			`{"email": "a@example.com"}`: true,
+			`{"email": "a@example.com", "phone": "1"}`: false,
+		},
+	} {
+		response, err := convert(prepareSampleProto(t, sampleProtos[sampleProtoName]))
+		if !assert.NoError(t, err, "Unable to convert sample proto (%v)", sampleProtoName) {
+			continue
+		}
+		schemaLoader := gojsonschema.NewStringLoader(response.File[0].GetContent())
+		for instance, expectedValid := range instances {
+			result, err := gojsonschema.Validate(schemaLoader, gojsonschema.NewStringLoader(instance))
+			if assert.NoError(t, err, "Unable to validate %v against sample proto (%v)", instance, sampleProtoName) {
+				assert.Equal(t, expectedValid, result.Valid(), "Incorrect validation of %v against sample proto (%v)", instance, sampleProtoName)
+			}
+		}
+	}
 }
 
 func testForProtocBinary(t *testing.T) {
@@ -75,17 +320,61 @@ func testForProtocBinary(t *testing.T) {
 
 func testConvertSampleProtos(t *testing.T, sampleProto SampleProto) {
 
+	// Prepare a request:
+	codeGeneratorRequest := prepareSampleProto(t, sampleProto)
+	sampleProtoFileName := fmt.Sprintf("%v/%v", sampleProtoDirectory, sampleProto.ProtoFileName)
+
+	// Perform the conversion:
+	response, err := convert(codeGeneratorRequest)
+	assert.NoError(t, err, "Unable to convert sample proto file (%v)", sampleProtoFileName)
+	assert.Equal(t, len(sampleProto.ExpectedJsonSchema), len(response.File), "Incorrect number of JSON-Schema files returned for sample proto file (%v)", sampleProtoFileName)
+	if len(sampleProto.ExpectedJsonSchema) != len(response.File) {
+		t.Fail()
+	} else {
+		for responseFileIndex, responseFile := range response.File {
+			assert.Equal(t, sampleProto.ExpectedJsonSchema[responseFileIndex], *responseFile.Content, "Incorrect JSON-Schema returned for sample proto file (%v)", sampleProtoFileName)
+			if sampleProto.ExpectedFileNames != nil {
+				assert.Equal(t, sampleProto.ExpectedFileNames[responseFileIndex], *responseFile.Name, "Incorrect JSON-Schema filename returned for sample proto file (%v)", sampleProtoFileName)
+			}
+		}
+	}
+}
+
+// Sets the parameters of a sample proto, and prepares a CodeGeneratorRequest for it:
+func prepareSampleProto(t *testing.T, sampleProto SampleProto) *plugin.CodeGeneratorRequest {
+
 	// Set allowNullValues accordingly:
 	allowNullValues = sampleProto.AllowNullValues
+	base64Bytes = sampleProto.Base64Bytes
+	baseURI = sampleProto.BaseURI
+	bundling = sampleProto.Bundle
 	disallowEnumOneOf = sampleProto.DisallowEnumOneOf
 	disallowOneOf = sampleProto.DisallowOneOf
 	disallowAdditionalProperties = sampleProto.DisallowAdditional
+	enforceOneOf = sampleProto.EnforceOneOf
+	externalRefs = sampleProto.ExternalRefs
+	outputFileNameTemplate = fileNameTemplate{}
+	if sampleProto.FileNameTemplate != "" {
+		assert.NoError(t, outputFileNameTemplate.Set(sampleProto.FileNameTemplate), "Invalid filename template (%v)", sampleProto.FileNameTemplate)
+	}
+	floatFormat = sampleProto.FloatFormat
+	floatValues = sampleProto.FloatValues
+	generateNestedTypes = sampleProto.GenerateNestedTypes
+	outputPaths = sampleProto.Paths
+	propertyOrder = sampleProto.PropertyOrder
+	propertyNames = sampleProto.PropertyNames
+	requireProto3Scalars = sampleProto.RequireProto3
+	useDefinitions = sampleProto.UseDefinitions
+	usePGVRules = sampleProto.UsePGVRules
+	schemaDraft = sampleProto.Draft
+	schemaFormat = sampleProto.Format
+	excludeCommentPrefixes = sampleProto.ExcludeCommentPrefixes
 
 	// Open the sample proto file:
 	sampleProtoFileName := fmt.Sprintf("%v/%v", sampleProtoDirectory, sampleProto.ProtoFileName)
 
 	// Prepare to run the "protoc" command (generates a CodeGeneratorRequest):
-	protocCommand := exec.Command(protocBinary, "--descriptor_set_out=/dev/stdout", "--include_imports", fmt.Sprintf("--proto_path=%v", sampleProtoDirectory), sampleProtoFileName)
+	protocCommand := exec.Command(protocBinary, "--descriptor_set_out=/dev/stdout", "--include_imports", "--include_source_info", fmt.Sprintf("--proto_path=%v", sampleProtoDirectory), fmt.Sprintf("--proto_path=%v", optionsProtoDirectory), sampleProtoFileName)
 	var protocCommandOutput bytes.Buffer
 	errChan := &bytes.Buffer{}
 	protocCommand.Stdout = &protocCommandOutput
@@ -99,30 +388,17 @@ func testConvertSampleProtos(t *testing.T, sampleProto SampleProto) {
 	err = proto.Unmarshal(protocCommandOutput.Bytes(), fileDescriptorSet)
 	assert.NoError(t, err, "Unable to unmarshal proto FileDescriptorSet for sample proto file (%v)", sampleProtoFileName)
 
-	// Prepare a request:
-	codeGeneratorRequest := plugin.CodeGeneratorRequest{
+	return &plugin.CodeGeneratorRequest{
 		FileToGenerate: sampleProto.FilesToGenerate,
 		ProtoFile:      fileDescriptorSet.GetFile(),
 	}
-
-	// Perform the conversion:
-	response, err := convert(&codeGeneratorRequest)
-	assert.NoError(t, err, "Unable to convert sample proto file (%v)", sampleProtoFileName)
-	assert.Equal(t, len(sampleProto.ExpectedJsonSchema), len(response.File), "Incorrect number of JSON-Schema files returned for sample proto file (%v)", sampleProtoFileName)
-	if len(sampleProto.ExpectedJsonSchema) != len(response.File) {
-		t.Fail()
-	} else {
-		for responseFileIndex, responseFile := range response.File {
-			assert.Equal(t, sampleProto.ExpectedJsonSchema[responseFileIndex], *responseFile.Content, "Incorrect JSON-Schema returned for sample proto file (%v)", sampleProtoFileName)
-		}
-	}
 }
 
 func configureSampleProtos() {
 	// ArrayOfMessages:
 	sampleProtos["ArrayOfMessages"] = SampleProto{
 		AllowNullValues:    false,
-		ExpectedJsonSchema: []string{testdata.PayloadMessage, testdata.ArrayOfMessages},
+		ExpectedJsonSchema: []string{testdata.ArrayOfMessages, testdata.PayloadMessage},
 		FilesToGenerate:    []string{"ArrayOfMessages.proto", "PayloadMessage.proto"},
 		ProtoFileName:      "ArrayOfMessages.proto",
 	}
@@ -151,14 +427,117 @@ func configureSampleProtos() {
 		ProtoFileName:      "ArrayOfPrimitives.proto",
 	}
 
+	// BundleFile:
+	sampleProtos["BundleFile"] = SampleProto{
+		AllowNullValues:    false,
+		Bundle:             bundleFile,
+		Paths:              pathsSourceRelative,
+		ExpectedFileNames:  []string{"billing/v1/Event.jsonschema", "audit/v2/Event.jsonschema", "EventCollisions.jsonschema"},
+		ExpectedJsonSchema: []string{testdata.BundleFileBillingEvent, testdata.BundleFileAuditEvent, testdata.BundleFileEventCollisions},
+		FilesToGenerate:    []string{"billing/v1/Event.proto", "audit/v2/Event.proto", "EventCollisions.proto"},
+		ProtoFileName:      "EventCollisions.proto",
+	}
+
+	// BundlePackage:
+	sampleProtos["BundlePackage"] = SampleProto{
+		AllowNullValues:     false,
+		Bundle:              bundlePackage,
+		GenerateNestedTypes: true,
+		ExpectedFileNames:   []string{"samples.jsonschema"},
+		ExpectedJsonSchema:  []string{testdata.BundlePackage},
+		FilesToGenerate:     []string{"PayloadMessage.proto", "NestedMessage.proto"},
+		ProtoFileName:       "NestedMessage.proto",
+	}
+
+	// BundlePackageNoPackage:
+	sampleProtos["BundlePackageNoPackage"] = SampleProto{
+		AllowNullValues:    false,
+		Bundle:             bundlePackage,
+		ExpectedFileNames:  []string{"default.jsonschema"},
+		ExpectedJsonSchema: []string{testdata.BundlePackageNoPackage},
+		FilesToGenerate:    []string{"NoPackage.proto"},
+		ProtoFileName:      "NoPackage.proto",
+	}
+
+	// BundleRecursionDraft202012:
+	sampleProtos["BundleRecursionDraft202012"] = SampleProto{
+		AllowNullValues:    false,
+		Bundle:             bundleFile,
+		Draft:              jsonschema.Draft202012,
+		ExpectedFileNames:  []string{"Recursion.jsonschema"},
+		ExpectedJsonSchema: []string{testdata.BundleRecursionDraft202012},
+		FilesToGenerate:    []string{"Recursion.proto"},
+		ProtoFileName:      "Recursion.proto",
+	}
+
+	// Bytes:
+	sampleProtos["Bytes"] = SampleProto{
+		AllowNullValues:    false,
+		Base64Bytes:        true,
+		ExpectedJsonSchema: []string{testdata.Bytes},
+		FilesToGenerate:    []string{"Bytes.proto"},
+		ProtoFileName:      "Bytes.proto",
+	}
+
+	// BytesDraft07:
+	sampleProtos["BytesDraft07"] = SampleProto{
+		AllowNullValues:    true,
+		Base64Bytes:        true,
+		Draft:              jsonschema.Draft07,
+		ExpectedJsonSchema: []string{testdata.BytesDraft07},
+		FilesToGenerate:    []string{"Bytes.proto"},
+		ProtoFileName:      "Bytes.proto",
+	}
+
+	// Comments:
+	sampleProtos["Comments"] = SampleProto{
+		AllowNullValues:        false,
+		UseDefinitions:         true,
+		ExcludeCommentPrefixes: []string{"@exclude"},
+		ExpectedJsonSchema:     []string{testdata.Comments},
+		FilesToGenerate:        []string{"Comments.proto"},
+		ProtoFileName:          "Comments.proto",
+	}
+
+	// CommentsYAML:
+	sampleProtos["CommentsYAML"] = SampleProto{
+		AllowNullValues:        false,
+		UseDefinitions:         true,
+		ExcludeCommentPrefixes: []string{"@exclude"},
+		Format:                 formatYAML,
+		ExpectedFileNames:      []string{"Comments.yaml"},
+		ExpectedJsonSchema:     []string{testdata.CommentsYAML},
+		FilesToGenerate:        []string{"Comments.proto"},
+		ProtoFileName:          "Comments.proto",
+	}
+
 	// EnumCeption:
 	sampleProtos["EnumCeption"] = SampleProto{
 		AllowNullValues:    false,
-		ExpectedJsonSchema: []string{testdata.PayloadMessage, testdata.ImportedEnum, testdata.EnumCeption},
+		ExpectedJsonSchema: []string{testdata.EnumCeption, testdata.PayloadMessage, testdata.ImportedEnum},
 		FilesToGenerate:    []string{"Enumception.proto", "PayloadMessage.proto", "ImportedEnum.proto"},
 		ProtoFileName:      "Enumception.proto",
 	}
 
+	// EnumCeptionWithDefinitions:
+	sampleProtos["EnumCeptionWithDefinitions"] = SampleProto{
+		AllowNullValues:    false,
+		UseDefinitions:     true,
+		ExpectedJsonSchema: []string{testdata.EnumCeptionWithDefinitions},
+		FilesToGenerate:    []string{"Enumception.proto"},
+		ProtoFileName:      "Enumception.proto",
+	}
+
+	// EnumCollisions:
+	sampleProtos["EnumCollisions"] = SampleProto{
+		AllowNullValues:    false,
+		DisallowEnumOneOf:  true,
+		DisallowOneOf:      true,
+		ExpectedJsonSchema: []string{testdata.OrderStatus, testdata.EnumCollisions},
+		FilesToGenerate:    []string{"EnumCollisions.proto"},
+		ProtoFileName:      "EnumCollisions.proto",
+	}
+
 	// EnumWithNoOneOf:
 	sampleProtos["EnumWithNoOneOf"] = SampleProto{
 		AllowNullValues:    false,
@@ -174,11 +553,98 @@ func configureSampleProtos() {
 		AllowNullValues:    false,
 		DisallowEnumOneOf:  true,
 		DisallowOneOf:      true,
-		ExpectedJsonSchema: []string{testdata.ExternalEnum},
+		ExpectedJsonSchema: []string{testdata.External, testdata.ExternalEnum},
 		FilesToGenerate:    []string{"ExternalEnum.proto"},
 		ProtoFileName:      "ExternalEnum.proto",
 	}
 
+	// ExternalRefsAbsolute:
+	sampleProtos["ExternalRefsAbsolute"] = SampleProto{
+		AllowNullValues:    true,
+		BaseURI:            "https://schemas.example.com/schemas",
+		ExternalRefs:       externalRefsAbsolute,
+		FileNameTemplate:   "{package}.{message}.json",
+		ExpectedFileNames:  []string{"billing.v1.Event.json", "audit.v2.Event.json", "samples.EventCollisions.json"},
+		ExpectedJsonSchema: []string{testdata.ExternalRefsAbsoluteBillingEvent, testdata.ExternalRefsAbsoluteAuditEvent, testdata.ExternalRefsAbsoluteEventCollisions},
+		FilesToGenerate:    []string{"billing/v1/Event.proto", "audit/v2/Event.proto", "EventCollisions.proto"},
+		ProtoFileName:      "EventCollisions.proto",
+	}
+
+	// ExternalRefsEnum:
+	sampleProtos["ExternalRefsEnum"] = SampleProto{
+		AllowNullValues:    false,
+		DisallowEnumOneOf:  true,
+		ExternalRefs:       externalRefsRelative,
+		Paths:              pathsSourceRelative,
+		ExpectedFileNames:  []string{"subpackage/Status.jsonschema", "OrderStatus.jsonschema", "EnumCollisions.jsonschema"},
+		ExpectedJsonSchema: []string{testdata.SubpackageStatus, testdata.OrderStatus, testdata.ExternalRefsEnum},
+		FilesToGenerate:    []string{"subpackage/Status.proto", "EnumCollisions.proto"},
+		ProtoFileName:      "EnumCollisions.proto",
+	}
+
+	// ExternalRefsNotGenerated (imported files which aren't being generated have no files to refer to, so they're copied in):
+	sampleProtos["ExternalRefsNotGenerated"] = SampleProto{
+		AllowNullValues:    false,
+		ExternalRefs:       externalRefsRelative,
+		ExpectedFileNames:  []string{"EventCollisions.jsonschema"},
+		ExpectedJsonSchema: []string{testdata.EventCollisions},
+		FilesToGenerate:    []string{"EventCollisions.proto"},
+		ProtoFileName:      "EventCollisions.proto",
+	}
+
+	// ExternalRefsRelative:
+	sampleProtos["ExternalRefsRelative"] = SampleProto{
+		AllowNullValues:    false,
+		BaseURI:            "https://schemas.example.com/",
+		Draft:              jsonschema.Draft202012,
+		ExternalRefs:       externalRefsRelative,
+		Paths:              pathsPackage,
+		ExpectedFileNames:  []string{"billing/v1/Event.jsonschema", "audit/v2/Event.jsonschema", "samples/EventCollisions.jsonschema"},
+		ExpectedJsonSchema: []string{testdata.ExternalRefsRelativeBillingEvent, testdata.ExternalRefsRelativeAuditEvent, testdata.ExternalRefsRelativeEventCollisions},
+		FilesToGenerate:    []string{"billing/v1/Event.proto", "audit/v2/Event.proto", "EventCollisions.proto"},
+		ProtoFileName:      "EventCollisions.proto",
+	}
+
+	// FileNameTemplate:
+	sampleProtos["FileNameTemplate"] = SampleProto{
+		AllowNullValues:    false,
+		FileNameTemplate:   "{version}/{message|kebab}.schema.json",
+		ExpectedFileNames:  []string{"v1/event.schema.json", "v2/event.schema.json", "event-collisions.schema.json"},
+		ExpectedJsonSchema: []string{testdata.BillingEvent, testdata.AuditEvent, testdata.EventCollisions},
+		FilesToGenerate:    []string{"billing/v1/Event.proto", "audit/v2/Event.proto", "EventCollisions.proto"},
+		ProtoFileName:      "EventCollisions.proto",
+	}
+
+	// FileNameTemplateNestedTypes:
+	sampleProtos["FileNameTemplateNestedTypes"] = SampleProto{
+		AllowNullValues:     false,
+		FileNameTemplate:    "{package|upper}_{file|snake}_{message|snake}.json",
+		GenerateNestedTypes: true,
+		ExpectedFileNames:   []string{"SAMPLES_nested_types_priority.json", "SAMPLES_nested_types_outer.json", "SAMPLES_nested_types_outer.inner.json", "SAMPLES_nested_types_outer.inner.leaf.json", "SAMPLES_nested_types_outer.status.json"},
+		ExpectedJsonSchema:  []string{testdata.NestedTypesPriority, testdata.NestedTypesOuter, testdata.NestedTypesOuterInner, testdata.NestedTypesOuterInnerLeaf, testdata.NestedTypesOuterStatus},
+		FilesToGenerate:     []string{"NestedTypes.proto"},
+		ProtoFileName:       "NestedTypes.proto",
+	}
+
+	// FloatsProtojson:
+	sampleProtos["FloatsProtojson"] = SampleProto{
+		AllowNullValues:    true,
+		FloatFormat:        true,
+		FloatValues:        floatValuesProtojson,
+		ExpectedJsonSchema: []string{testdata.FloatsProtojson},
+		FilesToGenerate:    []string{"Floats.proto"},
+		ProtoFileName:      "Floats.proto",
+	}
+
+	// FloatsStrict:
+	sampleProtos["FloatsStrict"] = SampleProto{
+		AllowNullValues:    false,
+		FloatValues:        floatValuesStrict,
+		ExpectedJsonSchema: []string{testdata.FloatsStrict},
+		FilesToGenerate:    []string{"Floats.proto"},
+		ProtoFileName:      "Floats.proto",
+	}
+
 	// ImportedEnum:
 	sampleProtos["ImportedEnum"] = SampleProto{
 		AllowNullValues:    false,
@@ -217,10 +683,62 @@ func configureSampleProtos() {
 		ProtoFileName:      "subpackageV2/ImportedMessageFromASiblingPackageWithEnum.proto",
 	}
 
+	// Integers:
+	sampleProtos["Integers"] = SampleProto{
+		AllowNullValues:    false,
+		ExpectedJsonSchema: []string{testdata.Integers},
+		FilesToGenerate:    []string{"Integers.proto"},
+		ProtoFileName:      "Integers.proto",
+	}
+
+	// Maps:
+	sampleProtos["Maps"] = SampleProto{
+		AllowNullValues:    false,
+		ExpectedJsonSchema: []string{testdata.Maps},
+		FilesToGenerate:    []string{"Maps.proto"},
+		ProtoFileName:      "Maps.proto",
+	}
+
+	// MapsAllowNullValues:
+	sampleProtos["MapsAllowNullValues"] = SampleProto{
+		AllowNullValues:    true,
+		ExpectedJsonSchema: []string{testdata.MapsAllowNullValues},
+		FilesToGenerate:    []string{"Maps.proto"},
+		ProtoFileName:      "Maps.proto",
+	}
+
+	// MapsYAML:
+	sampleProtos["MapsYAML"] = SampleProto{
+		AllowNullValues:    false,
+		Format:             formatYAML,
+		ExpectedFileNames:  []string{"Maps.yaml"},
+		ExpectedJsonSchema: []string{testdata.MapsYAML},
+		FilesToGenerate:    []string{"Maps.proto"},
+		ProtoFileName:      "Maps.proto",
+	}
+
+	// MapsDraft07:
+	sampleProtos["MapsDraft07"] = SampleProto{
+		AllowNullValues:    false,
+		Draft:              jsonschema.Draft07,
+		ExpectedJsonSchema: []string{testdata.MapsDraft07},
+		FilesToGenerate:    []string{"Maps.proto"},
+		ProtoFileName:      "Maps.proto",
+	}
+
+	// MixedEnumsAndMessages:
+	sampleProtos["MixedEnumsAndMessages"] = SampleProto{
+		AllowNullValues:    false,
+		ExpectedFileNames:  []string{"TaskState.jsonschema", "TaskPriority.jsonschema", "Task.jsonschema", "TaskList.jsonschema"},
+		ExpectedJsonSchema: []string{testdata.TaskState, testdata.TaskPriority, testdata.Task, testdata.TaskList},
+		FilesToGenerate:    []string{"MixedEnumsAndMessages.proto"},
+		ProtoFileName:      "MixedEnumsAndMessages.proto",
+	}
+
 	// NestedMessage:
 	sampleProtos["NestedMessage"] = SampleProto{
 		AllowNullValues:    false,
-		ExpectedJsonSchema: []string{testdata.PayloadMessage, testdata.NestedMessage},
+		ExpectedJsonSchema: []string{testdata.NestedMessage, testdata.PayloadMessage},
 		FilesToGenerate:    []string{"NestedMessage.proto", "PayloadMessage.proto"},
 		ProtoFileName:      "NestedMessage.proto",
 	}
@@ -229,7 +747,7 @@ func configureSampleProtos() {
 	sampleProtos["NestedMessageNoAdditionalProperties"] = SampleProto{
 		AllowNullValues:    false,
 		DisallowAdditional: true,
-		ExpectedJsonSchema: []string{testdata.PayloadMessageNoAdditionalProperties, testdata.NestedMessageNoAdditionalProperties},
+		ExpectedJsonSchema: []string{testdata.NestedMessageNoAdditionalProperties, testdata.PayloadMessageNoAdditionalProperties},
 		FilesToGenerate:    []string{"NestedMessage.proto", "PayloadMessage.proto"},
 		ProtoFileName:      "NestedMessage.proto",
 	}
@@ -242,6 +760,15 @@ func configureSampleProtos() {
 		ProtoFileName:      "NestedObject.proto",
 	}
 
+	// NestedTypes:
+	sampleProtos["NestedTypes"] = SampleProto{
+		AllowNullValues:     false,
+		GenerateNestedTypes: true,
+		ExpectedJsonSchema:  []string{testdata.NestedTypesPriority, testdata.NestedTypesOuter, testdata.NestedTypesOuterInner, testdata.NestedTypesOuterInnerLeaf, testdata.NestedTypesOuterStatus},
+		FilesToGenerate:     []string{"NestedTypes.proto"},
+		ProtoFileName:       "NestedTypes.proto",
+	}
+
 	// NoOneOf:
 	sampleProtos["NoOneOf"] = SampleProto{
 		AllowNullValues:    false,
@@ -252,6 +779,97 @@ func configureSampleProtos() {
 		ProtoFileName:      "NoOneOf.proto",
 	}
 
+	// NoPackage:
+	sampleProtos["NoPackage"] = SampleProto{
+		AllowNullValues:    false,
+		ExpectedFileNames:  []string{"Shape.jsonschema", "Canvas.jsonschema"},
+		ExpectedJsonSchema: []string{testdata.Shape, testdata.Canvas},
+		FilesToGenerate:    []string{"NoPackage.proto"},
+		ProtoFileName:      "NoPackage.proto",
+	}
+
+	// OneOf:
+	sampleProtos["OneOf"] = SampleProto{
+		AllowNullValues:    false,
+		ExpectedJsonSchema: []string{testdata.OneOf},
+		FilesToGenerate:    []string{"OneOf.proto"},
+		ProtoFileName:      "OneOf.proto",
+	}
+
+	// OneOfAllowNullValues:
+	sampleProtos["OneOfAllowNullValues"] = SampleProto{
+		AllowNullValues:    true,
+		ExpectedJsonSchema: []string{testdata.OneOfAllowNullValues},
+		FilesToGenerate:    []string{"OneOf.proto"},
+		ProtoFileName:      "OneOf.proto",
+	}
+
+	// OneOfEnforced:
+	sampleProtos["OneOfEnforced"] = SampleProto{
+		AllowNullValues:    false,
+		EnforceOneOf:       true,
+		ExpectedJsonSchema: []string{testdata.OneOfEnforced},
+		FilesToGenerate:    []string{"OneOf.proto"},
+		ProtoFileName:      "OneOf.proto",
+	}
+
+	// Options:
+	sampleProtos["Options"] = SampleProto{
+		AllowNullValues:    false,
+		ExpectedJsonSchema: []string{testdata.Options},
+		FilesToGenerate:    []string{"Options.proto"},
+		ProtoFileName:      "Options.proto",
+	}
+
+	// OptionsWithDefinitions:
+	sampleProtos["OptionsWithDefinitions"] = SampleProto{
+		AllowNullValues:    false,
+		UseDefinitions:     true,
+		ExpectedJsonSchema: []string{testdata.OptionsWithDefinitions},
+		FilesToGenerate:    []string{"Options.proto"},
+		ProtoFileName:      "Options.proto",
+	}
+
+	// OptionsWithDefinitionsDraft201909:
+	sampleProtos["OptionsWithDefinitionsDraft201909"] = SampleProto{
+		AllowNullValues:    false,
+		UseDefinitions:     true,
+		Draft:              jsonschema.Draft201909,
+		ExpectedJsonSchema: []string{testdata.OptionsWithDefinitionsDraft201909},
+		FilesToGenerate:    []string{"Options.proto"},
+		ProtoFileName:      "Options.proto",
+	}
+
+	// PathsFullyQualified:
+	sampleProtos["PathsFullyQualified"] = SampleProto{
+		AllowNullValues:    false,
+		Paths:              pathsFullyQualified,
+		ExpectedFileNames:  []string{"billing.v1.Event.jsonschema", "audit.v2.Event.jsonschema", "samples.EventCollisions.jsonschema"},
+		ExpectedJsonSchema: []string{testdata.BillingEvent, testdata.AuditEvent, testdata.EventCollisions},
+		FilesToGenerate:    []string{"billing/v1/Event.proto", "audit/v2/Event.proto", "EventCollisions.proto"},
+		ProtoFileName:      "EventCollisions.proto",
+	}
+
+	// PathsPackage:
+	sampleProtos["PathsPackage"] = SampleProto{
+		AllowNullValues:    false,
+		Paths:              pathsPackage,
+		ExpectedFileNames:  []string{"billing/v1/Event.jsonschema", "audit/v2/Event.jsonschema", "samples/EventCollisions.jsonschema"},
+		ExpectedJsonSchema: []string{testdata.BillingEvent, testdata.AuditEvent, testdata.EventCollisions},
+		FilesToGenerate:    []string{"billing/v1/Event.proto", "audit/v2/Event.proto", "EventCollisions.proto"},
+		ProtoFileName:      "EventCollisions.proto",
+	}
+
+	// PathsSourceRelative:
+	sampleProtos["PathsSourceRelative"] = SampleProto{
+		AllowNullValues:    false,
+		Paths:              pathsSourceRelative,
+		ExpectedFileNames:  []string{"billing/v1/Event.jsonschema", "audit/v2/Event.jsonschema", "EventCollisions.jsonschema"},
+		ExpectedJsonSchema: []string{testdata.BillingEvent, testdata.AuditEvent, testdata.EventCollisions},
+		FilesToGenerate:    []string{"billing/v1/Event.proto", "audit/v2/Event.proto", "EventCollisions.proto"},
+		ProtoFileName:      "EventCollisions.proto",
+	}
+
 	// PayloadMessage:
 	sampleProtos["PayloadMessage"] = SampleProto{
 		AllowNullValues:    false,
@@ -260,6 +878,101 @@ func configureSampleProtos() {
 		ProtoFileName:      "PayloadMessage.proto",
 	}
 
+	// PropertyOrder:
+	sampleProtos["PropertyOrder"] = SampleProto{
+		AllowNullValues:    false,
+		ExpectedJsonSchema: []string{testdata.PropertyOrder},
+		FilesToGenerate:    []string{"PropertyOrder.proto"},
+		ProtoFileName:      "PropertyOrder.proto",
+	}
+
+	// PropertyOrderDeclaration:
+	sampleProtos["PropertyOrderDeclaration"] = SampleProto{
+		AllowNullValues:    false,
+		PropertyOrder:      propertyOrderDeclaration,
+		ExpectedJsonSchema: []string{testdata.PropertyOrderDeclaration},
+		FilesToGenerate:    []string{"PropertyOrder.proto"},
+		ProtoFileName:      "PropertyOrder.proto",
+	}
+
+	// PropertyOrderFieldNumber:
+	sampleProtos["PropertyOrderFieldNumber"] = SampleProto{
+		AllowNullValues:    false,
+		PropertyOrder:      propertyOrderFieldNumber,
+		ExpectedJsonSchema: []string{testdata.PropertyOrderFieldNumber},
+		FilesToGenerate:    []string{"PropertyOrder.proto"},
+		ProtoFileName:      "PropertyOrder.proto",
+	}
+
+	// Proto2Required:
+	sampleProtos["Proto2Required"] = SampleProto{
+		AllowNullValues:    false,
+		ExpectedJsonSchema: []string{testdata.Proto2Required},
+		FilesToGenerate:    []string{"Proto2Required.proto"},
+		ProtoFileName:      "Proto2Required.proto",
+	}
+
+	// ProtoNames:
+	sampleProtos["ProtoNames"] = SampleProto{
+		AllowNullValues:    false,
+		PropertyNames:      propertyNamesProto,
+		ExpectedJsonSchema: []string{testdata.ProtoNames},
+		FilesToGenerate:    []string{"ProtoNames.proto"},
+		ProtoFileName:      "ProtoNames.proto",
+	}
+
+	// ProtoNamesBoth:
+	sampleProtos["ProtoNamesBoth"] = SampleProto{
+		AllowNullValues:    false,
+		PropertyNames:      propertyNamesBoth,
+		ExpectedJsonSchema: []string{testdata.ProtoNamesBoth},
+		FilesToGenerate:    []string{"ProtoNames.proto"},
+		ProtoFileName:      "ProtoNames.proto",
+	}
+
+	// ProtoNamesBothAllowNullValues:
+	sampleProtos["ProtoNamesBothAllowNullValues"] = SampleProto{
+		AllowNullValues:    true,
+		PropertyNames:      propertyNamesBoth,
+		ExpectedJsonSchema: []string{testdata.ProtoNamesBothAllowNullValues},
+		FilesToGenerate:    []string{"ProtoNames.proto"},
+		ProtoFileName:      "ProtoNames.proto",
+	}
+
+	// Proto3Optional:
+	sampleProtos["Proto3Optional"] = SampleProto{
+		AllowNullValues:    true,
+		ExpectedJsonSchema: []string{testdata.Proto3Optional},
+		FilesToGenerate:    []string{"Proto3Optional.proto"},
+		ProtoFileName:      "Proto3Optional.proto",
+	}
+
+	// Proto3RequiredScalars:
+	sampleProtos["Proto3RequiredScalars"] = SampleProto{
+		AllowNullValues:    false,
+		RequireProto3:      true,
+		ExpectedJsonSchema: []string{testdata.Proto3RequiredScalars},
+		FilesToGenerate:    []string{"Proto3Optional.proto"},
+		ProtoFileName:      "Proto3Optional.proto",
+	}
+
+	// Recursion:
+	sampleProtos["Recursion"] = SampleProto{
+		AllowNullValues:    false,
+		ExpectedJsonSchema: []string{testdata.Recursion},
+		FilesToGenerate:    []string{"Recursion.proto"},
+		ProtoFileName:      "Recursion.proto",
+	}
+
+	// RecursionDraft201909:
+	sampleProtos["RecursionDraft201909"] = SampleProto{
+		AllowNullValues:    false,
+		Draft:              jsonschema.Draft201909,
+		ExpectedJsonSchema: []string{testdata.RecursionDraft201909},
+		FilesToGenerate:    []string{"Recursion.proto"},
+		ProtoFileName:      "Recursion.proto",
+	}
+
 	// SeveralEnums:
 	sampleProtos["SeveralEnums"] = SampleProto{
 		AllowNullValues:    false,
@@ -283,4 +996,31 @@ func configureSampleProtos() {
 		FilesToGenerate:    []string{"Timestamp.proto"},
 		ProtoFileName:      "Timestamp.proto",
 	}
+
+	// ValidateRules:
+	sampleProtos["ValidateRules"] = SampleProto{
+		AllowNullValues:    false,
+		UsePGVRules:        true,
+		ExpectedJsonSchema: []string{testdata.ValidateRules},
+		FilesToGenerate:    []string{"ValidateRules.proto"},
+		ProtoFileName:      "ValidateRules.proto",
+	}
+
+	// ValidateRulesDraft202012:
+	sampleProtos["ValidateRulesDraft202012"] = SampleProto{
+		AllowNullValues:    false,
+		UsePGVRules:        true,
+		Draft:              jsonschema.Draft202012,
+		ExpectedJsonSchema: []string{testdata.ValidateRulesDraft202012},
+		FilesToGenerate:    []string{"ValidateRules.proto"},
+		ProtoFileName:      "ValidateRules.proto",
+	}
+
+	// WellKnown
+	sampleProtos["WellKnown"] = SampleProto{
+		AllowNullValues:    false,
+		ExpectedJsonSchema: []string{testdata.WellKnown},
+		FilesToGenerate:    []string{"WellKnown.proto"},
+		ProtoFileName:      "WellKnown.proto",
+	}
 }
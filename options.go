@@ -0,0 +1,189 @@
+package main
+
+import (
+	"encoding/json"
+	"fmt"
+
+	"github.com/RedVentures/protoc-gen-jsonschema/internal/jsonschema"
+	"github.com/RedVentures/protoc-gen-jsonschema/options"
+	"github.com/golang/protobuf/proto"
+	"github.com/golang/protobuf/protoc-gen-go/descriptor"
+	"github.com/xeipuuv/gojsonschema"
+)
+
+// The (jsonschema.file) options of the file each message was defined in:
+var messageFileOptions = make(map[*descriptor.DescriptorProto]*options.FileOptions)
+
+// Remembers the (jsonschema.file) options for every message in a proto file (including nested messages):
+func registerFileOptions(fileOptions *options.FileOptions, msgs []*descriptor.DescriptorProto) {
+	for _, msg := range msgs {
+		messageFileOptions[msg] = fileOptions
+		registerFileOptions(fileOptions, msg.GetNestedType())
+	}
+}
+
+// Reads the (jsonschema.file) options of a proto file:
+func getFileOptions(file *descriptor.FileDescriptorProto) (*options.FileOptions, error) {
+	if !proto.HasExtension(file.GetOptions(), options.E_File) {
+		return nil, nil
+	}
+	extension, err := proto.GetExtension(file.GetOptions(), options.E_File)
+	if err != nil {
+		return nil, fmt.Errorf("unable to read (jsonschema.file) options of %s: %v", file.GetName(), err)
+	}
+	return extension.(*options.FileOptions), nil
+}
+
+// Reads the (jsonschema.message) options of a message:
+func getMessageOptions(msg *descriptor.DescriptorProto) (*options.MessageOptions, error) {
+	if !proto.HasExtension(msg.GetOptions(), options.E_Message) {
+		return nil, nil
+	}
+	extension, err := proto.GetExtension(msg.GetOptions(), options.E_Message)
+	if err != nil {
+		return nil, fmt.Errorf("unable to read (jsonschema.message) options of %s: %v", msg.GetName(), err)
+	}
+	return extension.(*options.MessageOptions), nil
+}
+
+// Reads the (jsonschema.field) options of a field:
+func getFieldOptions(desc *descriptor.FieldDescriptorProto) (*options.FieldOptions, error) {
+	if !proto.HasExtension(desc.GetOptions(), options.E_Field) {
+		return nil, nil
+	}
+	extension, err := proto.GetExtension(desc.GetOptions(), options.E_Field)
+	if err != nil {
+		return nil, fmt.Errorf("unable to read (jsonschema.field) options of %s: %v", desc.GetName(), err)
+	}
+	return extension.(*options.FieldOptions), nil
+}
+
+// Reads the (jsonschema.enum) options of an enum:
+func getEnumOptions(enum *descriptor.EnumDescriptorProto) (*options.EnumOptions, error) {
+	if !proto.HasExtension(enum.GetOptions(), options.E_Enum) {
+		return nil, nil
+	}
+	extension, err := proto.GetExtension(enum.GetOptions(), options.E_Enum)
+	if err != nil {
+		return nil, fmt.Errorf("unable to read (jsonschema.enum) options of %s: %v", enum.GetName(), err)
+	}
+	return extension.(*options.EnumOptions), nil
+}
+
+// Whether extra properties should be rejected for a message. Message options take precedence over file options,
+// which take precedence over the disallow_additional_properties parameter:
+func disallowAdditionalPropertiesFor(msg *descriptor.DescriptorProto) (bool, error) {
+	messageOptions, err := getMessageOptions(msg)
+	if err != nil {
+		return false, err
+	}
+	if messageOptions != nil && messageOptions.DisallowAdditionalProperties != nil {
+		return messageOptions.GetDisallowAdditionalProperties(), nil
+	}
+	if fileOptions := messageFileOptions[msg]; fileOptions != nil && fileOptions.DisallowAdditionalProperties != nil {
+		return fileOptions.GetDisallowAdditionalProperties(), nil
+	}
+	return disallowAdditionalProperties, nil
+}
+
+// Merges the (jsonschema.message) options of a message into its JSON-Schema:
+func applyMessageOptions(jsonSchemaType *jsonschema.Type, msg *descriptor.DescriptorProto) error {
+	messageOptions, err := getMessageOptions(msg)
+	if err != nil || messageOptions == nil {
+		return err
+	}
+
+	if messageOptions.Title != nil {
+		jsonSchemaType.Title = messageOptions.GetTitle()
+	}
+	if messageOptions.Description != nil {
+		jsonSchemaType.Description = messageOptions.GetDescription()
+	}
+
+	return nil
+}
+
+// Merges the (jsonschema.field) options of a field into its JSON-Schema:
+func applyFieldOptions(jsonSchemaType *jsonschema.Type, desc *descriptor.FieldDescriptorProto) error {
+	fieldOptions, err := getFieldOptions(desc)
+	if err != nil || fieldOptions == nil {
+		return err
+	}
+
+	if fieldOptions.Title != nil {
+		jsonSchemaType.Title = fieldOptions.GetTitle()
+	}
+	if fieldOptions.Description != nil {
+		jsonSchemaType.Description = fieldOptions.GetDescription()
+	}
+
+	// Array constraints apply to the field itself:
+	if fieldOptions.MinItems != nil {
+		jsonSchemaType.MinItems = jsonschema.Count(fieldOptions.GetMinItems())
+	}
+	if fieldOptions.MaxItems != nil {
+		jsonSchemaType.MaxItems = jsonschema.Count(fieldOptions.GetMaxItems())
+	}
+	if fieldOptions.UniqueItems != nil {
+		jsonSchemaType.UniqueItems = fieldOptions.GetUniqueItems()
+	}
+	if fieldOptions.Default != nil {
+		if err := json.Unmarshal([]byte(fieldOptions.GetDefault()), &jsonSchemaType.Default); err != nil {
+			return fmt.Errorf("invalid default value for field %s (it needs to be JSON): %v", desc.GetName(), err)
+		}
+	}
+
+	// Everything else applies to the value:
+	valueJSONSchemaType := valueSchema(jsonSchemaType)
+
+	if fieldOptions.MinLength != nil {
+		valueJSONSchemaType.MinLength = jsonschema.Count(fieldOptions.GetMinLength())
+	}
+	if fieldOptions.MaxLength != nil {
+		valueJSONSchemaType.MaxLength = jsonschema.Count(fieldOptions.GetMaxLength())
+	}
+	if fieldOptions.Pattern != nil {
+		// Any pattern from PGV rules (or for base64) still applies:
+		addPattern(valueJSONSchemaType, fieldOptions.GetPattern())
+	}
+	if fieldOptions.Format != nil {
+		valueJSONSchemaType.Format = fieldOptions.GetFormat()
+	}
+	if fieldOptions.Minimum != nil {
+		if valueJSONSchemaType.Minimum, err = jsonschema.Float(fieldOptions.GetMinimum(), 64); err != nil {
+			return fmt.Errorf("invalid minimum for field %s: %v", desc.GetName(), err)
+		}
+	}
+	if fieldOptions.Maximum != nil {
+		if valueJSONSchemaType.Maximum, err = jsonschema.Float(fieldOptions.GetMaximum(), 64); err != nil {
+			return fmt.Errorf("invalid maximum for field %s: %v", desc.GetName(), err)
+		}
+	}
+
+	return nil
+}
+
+// Returns the part of a field's JSON-Schema which describes its value (which is each of the items for arrays):
+func valueSchema(jsonSchemaType *jsonschema.Type) *jsonschema.Type {
+	if jsonSchemaType.Type == gojsonschema.TYPE_ARRAY && jsonSchemaType.Items != nil {
+		return jsonSchemaType.Items
+	}
+	return jsonSchemaType
+}
+
+// Merges the (jsonschema.enum) options of an enum into its JSON-Schema:
+func applyEnumOptions(jsonSchemaType *jsonschema.Type, enum *descriptor.EnumDescriptorProto) error {
+	enumOptions, err := getEnumOptions(enum)
+	if err != nil || enumOptions == nil {
+		return err
+	}
+
+	if enumOptions.Title != nil {
+		jsonSchemaType.Title = enumOptions.GetTitle()
+	}
+	if enumOptions.Description != nil {
+		jsonSchemaType.Description = enumOptions.GetDescription()
+	}
+
+	return nil
+}
@@ -0,0 +1,380 @@
+// Code generated by protoc-gen-go. DO NOT EDIT.
+// source: jsonschema.proto
+
+package options // import "github.com/RedVentures/protoc-gen-jsonschema/options"
+
+import proto "github.com/golang/protobuf/proto"
+import fmt "fmt"
+import math "math"
+import descriptor "github.com/golang/protobuf/protoc-gen-go/descriptor"
+
+// Reference imports to suppress errors if they are not otherwise used.
+var _ = proto.Marshal
+var _ = fmt.Errorf
+var _ = math.Inf
+
+// This is a compile-time assertion to ensure that this generated file
+// is compatible with the proto package it is being compiled against.
+// A compilation error at this line likely means your copy of the
+// proto package needs to be updated.
+const _ = proto.ProtoPackageIsVersion2 // please upgrade the proto package
+
+// Constraints for a field. For repeated fields, everything except min_items, max_items, unique_items and default
+// applies to each item:
+type FieldOptions struct {
+	// Overrides the title and description taken from the comments on the field:
+	Title       *string `protobuf:"bytes,1,opt,name=title" json:"title,omitempty"`
+	Description *string `protobuf:"bytes,2,opt,name=description" json:"description,omitempty"`
+	// Strings:
+	MinLength *uint64 `protobuf:"varint,3,opt,name=min_length,json=minLength" json:"min_length,omitempty"`
+	MaxLength *uint64 `protobuf:"varint,4,opt,name=max_length,json=maxLength" json:"max_length,omitempty"`
+	Pattern   *string `protobuf:"bytes,5,opt,name=pattern" json:"pattern,omitempty"`
+	Format    *string `protobuf:"bytes,6,opt,name=format" json:"format,omitempty"`
+	// Numbers:
+	Minimum *float64 `protobuf:"fixed64,7,opt,name=minimum" json:"minimum,omitempty"`
+	Maximum *float64 `protobuf:"fixed64,8,opt,name=maximum" json:"maximum,omitempty"`
+	// Repeated fields:
+	MinItems    *uint64 `protobuf:"varint,9,opt,name=min_items,json=minItems" json:"min_items,omitempty"`
+	MaxItems    *uint64 `protobuf:"varint,10,opt,name=max_items,json=maxItems" json:"max_items,omitempty"`
+	UniqueItems *bool   `protobuf:"varint,11,opt,name=unique_items,json=uniqueItems" json:"unique_items,omitempty"`
+	// The default value, as JSON (eg "42", "\"text\"" or "[1, 2]"):
+	Default              *string  `protobuf:"bytes,12,opt,name=default" json:"default,omitempty"`
+	XXX_NoUnkeyedLiteral struct{} `json:"-"`
+	XXX_unrecognized     []byte   `json:"-"`
+	XXX_sizecache        int32    `json:"-"`
+}
+
+func (m *FieldOptions) Reset()         { *m = FieldOptions{} }
+func (m *FieldOptions) String() string { return proto.CompactTextString(m) }
+func (*FieldOptions) ProtoMessage()    {}
+func (*FieldOptions) Descriptor() ([]byte, []int) {
+	return fileDescriptor_jsonschema_2323ca3e68bba03d, []int{0}
+}
+func (m *FieldOptions) XXX_Unmarshal(b []byte) error {
+	return xxx_messageInfo_FieldOptions.Unmarshal(m, b)
+}
+func (m *FieldOptions) XXX_Marshal(b []byte, deterministic bool) ([]byte, error) {
+	return xxx_messageInfo_FieldOptions.Marshal(b, m, deterministic)
+}
+func (dst *FieldOptions) XXX_Merge(src proto.Message) {
+	xxx_messageInfo_FieldOptions.Merge(dst, src)
+}
+func (m *FieldOptions) XXX_Size() int {
+	return xxx_messageInfo_FieldOptions.Size(m)
+}
+func (m *FieldOptions) XXX_DiscardUnknown() {
+	xxx_messageInfo_FieldOptions.DiscardUnknown(m)
+}
+
+var xxx_messageInfo_FieldOptions proto.InternalMessageInfo
+
+func (m *FieldOptions) GetTitle() string {
+	if m != nil && m.Title != nil {
+		return *m.Title
+	}
+	return ""
+}
+
+func (m *FieldOptions) GetDescription() string {
+	if m != nil && m.Description != nil {
+		return *m.Description
+	}
+	return ""
+}
+
+func (m *FieldOptions) GetMinLength() uint64 {
+	if m != nil && m.MinLength != nil {
+		return *m.MinLength
+	}
+	return 0
+}
+
+func (m *FieldOptions) GetMaxLength() uint64 {
+	if m != nil && m.MaxLength != nil {
+		return *m.MaxLength
+	}
+	return 0
+}
+
+func (m *FieldOptions) GetPattern() string {
+	if m != nil && m.Pattern != nil {
+		return *m.Pattern
+	}
+	return ""
+}
+
+func (m *FieldOptions) GetFormat() string {
+	if m != nil && m.Format != nil {
+		return *m.Format
+	}
+	return ""
+}
+
+func (m *FieldOptions) GetMinimum() float64 {
+	if m != nil && m.Minimum != nil {
+		return *m.Minimum
+	}
+	return 0
+}
+
+func (m *FieldOptions) GetMaximum() float64 {
+	if m != nil && m.Maximum != nil {
+		return *m.Maximum
+	}
+	return 0
+}
+
+func (m *FieldOptions) GetMinItems() uint64 {
+	if m != nil && m.MinItems != nil {
+		return *m.MinItems
+	}
+	return 0
+}
+
+func (m *FieldOptions) GetMaxItems() uint64 {
+	if m != nil && m.MaxItems != nil {
+		return *m.MaxItems
+	}
+	return 0
+}
+
+func (m *FieldOptions) GetUniqueItems() bool {
+	if m != nil && m.UniqueItems != nil {
+		return *m.UniqueItems
+	}
+	return false
+}
+
+func (m *FieldOptions) GetDefault() string {
+	if m != nil && m.Default != nil {
+		return *m.Default
+	}
+	return ""
+}
+
+// Options for every message in a file:
+type FileOptions struct {
+	DisallowAdditionalProperties *bool    `protobuf:"varint,1,opt,name=disallow_additional_properties,json=disallowAdditionalProperties" json:"disallow_additional_properties,omitempty"`
+	XXX_NoUnkeyedLiteral         struct{} `json:"-"`
+	XXX_unrecognized             []byte   `json:"-"`
+	XXX_sizecache                int32    `json:"-"`
+}
+
+func (m *FileOptions) Reset()         { *m = FileOptions{} }
+func (m *FileOptions) String() string { return proto.CompactTextString(m) }
+func (*FileOptions) ProtoMessage()    {}
+func (*FileOptions) Descriptor() ([]byte, []int) {
+	return fileDescriptor_jsonschema_2323ca3e68bba03d, []int{1}
+}
+func (m *FileOptions) XXX_Unmarshal(b []byte) error {
+	return xxx_messageInfo_FileOptions.Unmarshal(m, b)
+}
+func (m *FileOptions) XXX_Marshal(b []byte, deterministic bool) ([]byte, error) {
+	return xxx_messageInfo_FileOptions.Marshal(b, m, deterministic)
+}
+func (dst *FileOptions) XXX_Merge(src proto.Message) {
+	xxx_messageInfo_FileOptions.Merge(dst, src)
+}
+func (m *FileOptions) XXX_Size() int {
+	return xxx_messageInfo_FileOptions.Size(m)
+}
+func (m *FileOptions) XXX_DiscardUnknown() {
+	xxx_messageInfo_FileOptions.DiscardUnknown(m)
+}
+
+var xxx_messageInfo_FileOptions proto.InternalMessageInfo
+
+func (m *FileOptions) GetDisallowAdditionalProperties() bool {
+	if m != nil && m.DisallowAdditionalProperties != nil {
+		return *m.DisallowAdditionalProperties
+	}
+	return false
+}
+
+// Options for a message (these take precedence over file options and plugin parameters):
+type MessageOptions struct {
+	// Overrides the title and description taken from the comments on the message:
+	Title                        *string  `protobuf:"bytes,1,opt,name=title" json:"title,omitempty"`
+	Description                  *string  `protobuf:"bytes,2,opt,name=description" json:"description,omitempty"`
+	DisallowAdditionalProperties *bool    `protobuf:"varint,3,opt,name=disallow_additional_properties,json=disallowAdditionalProperties" json:"disallow_additional_properties,omitempty"`
+	XXX_NoUnkeyedLiteral         struct{} `json:"-"`
+	XXX_unrecognized             []byte   `json:"-"`
+	XXX_sizecache                int32    `json:"-"`
+}
+
+func (m *MessageOptions) Reset()         { *m = MessageOptions{} }
+func (m *MessageOptions) String() string { return proto.CompactTextString(m) }
+func (*MessageOptions) ProtoMessage()    {}
+func (*MessageOptions) Descriptor() ([]byte, []int) {
+	return fileDescriptor_jsonschema_2323ca3e68bba03d, []int{2}
+}
+func (m *MessageOptions) XXX_Unmarshal(b []byte) error {
+	return xxx_messageInfo_MessageOptions.Unmarshal(m, b)
+}
+func (m *MessageOptions) XXX_Marshal(b []byte, deterministic bool) ([]byte, error) {
+	return xxx_messageInfo_MessageOptions.Marshal(b, m, deterministic)
+}
+func (dst *MessageOptions) XXX_Merge(src proto.Message) {
+	xxx_messageInfo_MessageOptions.Merge(dst, src)
+}
+func (m *MessageOptions) XXX_Size() int {
+	return xxx_messageInfo_MessageOptions.Size(m)
+}
+func (m *MessageOptions) XXX_DiscardUnknown() {
+	xxx_messageInfo_MessageOptions.DiscardUnknown(m)
+}
+
+var xxx_messageInfo_MessageOptions proto.InternalMessageInfo
+
+func (m *MessageOptions) GetTitle() string {
+	if m != nil && m.Title != nil {
+		return *m.Title
+	}
+	return ""
+}
+
+func (m *MessageOptions) GetDescription() string {
+	if m != nil && m.Description != nil {
+		return *m.Description
+	}
+	return ""
+}
+
+func (m *MessageOptions) GetDisallowAdditionalProperties() bool {
+	if m != nil && m.DisallowAdditionalProperties != nil {
+		return *m.DisallowAdditionalProperties
+	}
+	return false
+}
+
+// Options for an enum:
+type EnumOptions struct {
+	// Overrides the title and description taken from the comments on the enum:
+	Title                *string  `protobuf:"bytes,1,opt,name=title" json:"title,omitempty"`
+	Description          *string  `protobuf:"bytes,2,opt,name=description" json:"description,omitempty"`
+	XXX_NoUnkeyedLiteral struct{} `json:"-"`
+	XXX_unrecognized     []byte   `json:"-"`
+	XXX_sizecache        int32    `json:"-"`
+}
+
+func (m *EnumOptions) Reset()         { *m = EnumOptions{} }
+func (m *EnumOptions) String() string { return proto.CompactTextString(m) }
+func (*EnumOptions) ProtoMessage()    {}
+func (*EnumOptions) Descriptor() ([]byte, []int) {
+	return fileDescriptor_jsonschema_2323ca3e68bba03d, []int{3}
+}
+func (m *EnumOptions) XXX_Unmarshal(b []byte) error {
+	return xxx_messageInfo_EnumOptions.Unmarshal(m, b)
+}
+func (m *EnumOptions) XXX_Marshal(b []byte, deterministic bool) ([]byte, error) {
+	return xxx_messageInfo_EnumOptions.Marshal(b, m, deterministic)
+}
+func (dst *EnumOptions) XXX_Merge(src proto.Message) {
+	xxx_messageInfo_EnumOptions.Merge(dst, src)
+}
+func (m *EnumOptions) XXX_Size() int {
+	return xxx_messageInfo_EnumOptions.Size(m)
+}
+func (m *EnumOptions) XXX_DiscardUnknown() {
+	xxx_messageInfo_EnumOptions.DiscardUnknown(m)
+}
+
+var xxx_messageInfo_EnumOptions proto.InternalMessageInfo
+
+func (m *EnumOptions) GetTitle() string {
+	if m != nil && m.Title != nil {
+		return *m.Title
+	}
+	return ""
+}
+
+func (m *EnumOptions) GetDescription() string {
+	if m != nil && m.Description != nil {
+		return *m.Description
+	}
+	return ""
+}
+
+var E_Field = &proto.ExtensionDesc{
+	ExtendedType:  (*descriptor.FieldOptions)(nil),
+	ExtensionType: (*FieldOptions)(nil),
+	Field:         1125,
+	Name:          "jsonschema.field",
+	Tag:           "bytes,1125,opt,name=field",
+	Filename:      "jsonschema.proto",
+}
+
+var E_File = &proto.ExtensionDesc{
+	ExtendedType:  (*descriptor.FileOptions)(nil),
+	ExtensionType: (*FileOptions)(nil),
+	Field:         1126,
+	Name:          "jsonschema.file",
+	Tag:           "bytes,1126,opt,name=file",
+	Filename:      "jsonschema.proto",
+}
+
+var E_Message = &proto.ExtensionDesc{
+	ExtendedType:  (*descriptor.MessageOptions)(nil),
+	ExtensionType: (*MessageOptions)(nil),
+	Field:         1127,
+	Name:          "jsonschema.message",
+	Tag:           "bytes,1127,opt,name=message",
+	Filename:      "jsonschema.proto",
+}
+
+var E_Enum = &proto.ExtensionDesc{
+	ExtendedType:  (*descriptor.EnumOptions)(nil),
+	ExtensionType: (*EnumOptions)(nil),
+	Field:         1128,
+	Name:          "jsonschema.enum",
+	Tag:           "bytes,1128,opt,name=enum",
+	Filename:      "jsonschema.proto",
+}
+
+func init() {
+	proto.RegisterType((*FieldOptions)(nil), "jsonschema.FieldOptions")
+	proto.RegisterType((*FileOptions)(nil), "jsonschema.FileOptions")
+	proto.RegisterType((*MessageOptions)(nil), "jsonschema.MessageOptions")
+	proto.RegisterType((*EnumOptions)(nil), "jsonschema.EnumOptions")
+	proto.RegisterExtension(E_Field)
+	proto.RegisterExtension(E_File)
+	proto.RegisterExtension(E_Message)
+	proto.RegisterExtension(E_Enum)
+}
+
+func init() { proto.RegisterFile("jsonschema.proto", fileDescriptor_jsonschema_2323ca3e68bba03d) }
+
+var fileDescriptor_jsonschema_2323ca3e68bba03d = []byte{
+	// 484 bytes of a gzipped FileDescriptorProto
+	0x1f, 0x8b, 0x08, 0x00, 0x00, 0x00, 0x00, 0x00, 0x02, 0xff, 0xa4, 0x94, 0x4d, 0x6f, 0xd3, 0x40,
+	0x10, 0x86, 0xe5, 0x36, 0x69, 0x92, 0x71, 0x84, 0x90, 0x85, 0x60, 0x55, 0x5a, 0x30, 0x39, 0xe5,
+	0x52, 0x47, 0x42, 0x88, 0x43, 0x6e, 0x20, 0x5a, 0x09, 0xc4, 0x97, 0x16, 0xa9, 0x07, 0x2e, 0xd1,
+	0x36, 0x1e, 0x3b, 0x8b, 0xf6, 0xc3, 0x78, 0xd7, 0xc2, 0xbf, 0x82, 0xff, 0xc8, 0x81, 0x8f, 0x9f,
+	0x81, 0xbc, 0x6b, 0xa7, 0x8e, 0x88, 0x44, 0xa5, 0x1e, 0x67, 0x9e, 0x77, 0x5f, 0xbd, 0x99, 0x99,
+	0x18, 0xee, 0x7e, 0x31, 0x5a, 0x99, 0xf5, 0x06, 0x25, 0x4b, 0x8a, 0x52, 0x5b, 0x1d, 0xc1, 0x75,
+	0xe7, 0x38, 0xce, 0xb5, 0xce, 0x05, 0x2e, 0x1c, 0xb9, 0xaa, 0xb2, 0x45, 0x8a, 0x66, 0x5d, 0xf2,
+	0xc2, 0xea, 0xd2, 0xab, 0x67, 0x3f, 0x0e, 0x60, 0x7a, 0xc1, 0x51, 0xa4, 0x1f, 0x0a, 0xcb, 0xb5,
+	0x32, 0xd1, 0x3d, 0x18, 0x5a, 0x6e, 0x05, 0x92, 0x20, 0x0e, 0xe6, 0x13, 0xea, 0x8b, 0x28, 0x86,
+	0xb0, 0x7b, 0xca, 0xb5, 0x22, 0x07, 0x8e, 0xf5, 0x5b, 0xd1, 0x29, 0x80, 0xe4, 0x6a, 0x25, 0x50,
+	0xe5, 0x76, 0x43, 0x0e, 0xe3, 0x60, 0x3e, 0xa0, 0x13, 0xc9, 0xd5, 0x5b, 0xd7, 0x70, 0x98, 0xd5,
+	0x1d, 0x1e, 0xb4, 0x98, 0xd5, 0x2d, 0x26, 0x30, 0x2a, 0x98, 0xb5, 0x58, 0x2a, 0x32, 0x74, 0xde,
+	0x5d, 0x19, 0xdd, 0x87, 0xa3, 0x4c, 0x97, 0x92, 0x59, 0x72, 0xe4, 0x40, 0x5b, 0x35, 0x2f, 0x24,
+	0x57, 0x5c, 0x56, 0x92, 0x8c, 0xe2, 0x60, 0x1e, 0xd0, 0xae, 0x74, 0x84, 0xd5, 0x8e, 0x8c, 0x5b,
+	0xe2, 0xcb, 0xe8, 0x21, 0x34, 0x89, 0x56, 0xdc, 0xa2, 0x34, 0x64, 0xe2, 0x32, 0x8c, 0x25, 0x57,
+	0xaf, 0x9b, 0xda, 0x41, 0x56, 0xb7, 0x10, 0x5a, 0xc8, 0x6a, 0x0f, 0x9f, 0xc0, 0xb4, 0x52, 0xfc,
+	0x6b, 0x85, 0x2d, 0x0f, 0xe3, 0x60, 0x3e, 0xa6, 0xa1, 0xef, 0x79, 0x09, 0x81, 0x51, 0x8a, 0x19,
+	0xab, 0x84, 0x25, 0x53, 0xff, 0x13, 0xda, 0x72, 0xf6, 0x09, 0xc2, 0x0b, 0x2e, 0xb0, 0x9b, 0xf0,
+	0x2b, 0x78, 0x94, 0x72, 0xc3, 0x84, 0xd0, 0xdf, 0x56, 0x2c, 0x4d, 0x79, 0xd3, 0x65, 0x62, 0x55,
+	0x94, 0xba, 0xc0, 0xd2, 0x72, 0x34, 0x6e, 0xf4, 0x63, 0x7a, 0xd2, 0xa9, 0x5e, 0x6c, 0x45, 0x1f,
+	0xb7, 0x9a, 0xd9, 0xf7, 0x00, 0xee, 0xbc, 0x43, 0x63, 0x58, 0x8e, 0xb7, 0x5d, 0xdd, 0xff, 0x03,
+	0x1d, 0xde, 0x20, 0xd0, 0x39, 0x84, 0xe7, 0xaa, 0x92, 0xb7, 0x0c, 0xb3, 0x7c, 0x0f, 0xc3, 0xac,
+	0xb9, 0xc7, 0xe8, 0x34, 0xf1, 0xc7, 0x9b, 0x74, 0xc7, 0x9b, 0xf4, 0xef, 0x94, 0xfc, 0x6c, 0x76,
+	0x1b, 0x3e, 0x25, 0x49, 0xef, 0x0f, 0xd0, 0x17, 0x50, 0x6f, 0xb3, 0x7c, 0x03, 0x83, 0x8c, 0x0b,
+	0x8c, 0x4e, 0xf6, 0xd8, 0x6d, 0x77, 0x42, 0x7e, 0x79, 0xb7, 0x07, 0xbb, 0x6e, 0x5b, 0x4e, 0x9d,
+	0xc7, 0xf2, 0x12, 0x46, 0xd2, 0x8f, 0x3c, 0x7a, 0xfc, 0x8f, 0xdd, 0xee, 0x32, 0xc8, 0x6f, 0xef,
+	0x78, 0xdc, 0x77, 0xdc, 0x95, 0xd0, 0xce, 0xac, 0xc9, 0x88, 0xaa, 0x92, 0x7b, 0x32, 0xf6, 0x26,
+	0x4a, 0xfe, 0xec, 0xc9, 0xd8, 0xe3, 0xd4, 0x79, 0xbc, 0x7c, 0xfe, 0xf9, 0x59, 0xce, 0xed, 0xa6,
+	0xba, 0x4a, 0xd6, 0x5a, 0x2e, 0x28, 0xa6, 0x97, 0xa8, 0x6c, 0x55, 0xa2, 0xf1, 0x1f, 0x81, 0xf5,
+	0x59, 0x8e, 0xea, 0xec, 0xda, 0x61, 0xa1, 0xfd, 0xeb, 0xbf, 0x03, 0x00, 0x23, 0x56, 0x2f, 0x18,
+	0x49, 0x04, 0x00, 0x00,
+}
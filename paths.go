@@ -0,0 +1,57 @@
+package main
+
+import (
+	"fmt"
+	"path"
+	"strings"
+
+	"github.com/golang/protobuf/protoc-gen-go/descriptor"
+)
+
+// outputPathLayout is where output files are written (set with the paths parameter).
+type outputPathLayout int
+
+const (
+	pathsFlat           outputPathLayout = iota // Named after the message, in the output root (the default)
+	pathsPackage                                // In a directory for the proto package (eg "billing/v1/Event.jsonschema")
+	pathsSourceRelative                         // In the same directory as the proto file
+	pathsFullyQualified                         // Named after the fully-qualified message, in the output root (eg "billing.v1.Event.jsonschema")
+)
+
+var outputPathLayoutNames = map[outputPathLayout]string{
+	pathsFlat:           "flat",
+	pathsPackage:        "package",
+	pathsSourceRelative: "source_relative",
+	pathsFullyQualified: "fully_qualified",
+}
+
+func (l outputPathLayout) String() string {
+	return outputPathLayoutNames[l]
+}
+
+// Set parses an output path layout (this makes it a flag.Value).
+func (l *outputPathLayout) Set(name string) error {
+	for layout, layoutName := range outputPathLayoutNames {
+		if layoutName == name {
+			*l = layout
+			return nil
+		}
+	}
+	return fmt.Errorf("unsupported paths %q (expected flat, package, source_relative or fully_qualified)", name)
+}
+
+// Returns the name of the output file for a message or enum (typeName is its path within the proto file, eg "Outer.Inner"):
+func outputFileName(file *descriptor.FileDescriptorProto, typeName string) string {
+	fileName := outputFileNameTemplate.execute(file, typeName)
+	switch outputPaths {
+	case pathsPackage:
+		return path.Join(strings.Replace(file.GetPackage(), ".", "/", -1), fileName)
+	case pathsSourceRelative:
+		return path.Join(path.Dir(file.GetName()), fileName)
+	case pathsFullyQualified:
+		if file.GetPackage() != "" {
+			return file.GetPackage() + "." + fileName
+		}
+	}
+	return fileName
+}
@@ -0,0 +1,564 @@
+package main
+
+import (
+	"encoding/binary"
+	"encoding/json"
+	"fmt"
+	"math"
+	"regexp"
+
+	"github.com/RedVentures/protoc-gen-jsonschema/internal/jsonschema"
+	"github.com/golang/protobuf/proto"
+	"github.com/golang/protobuf/protoc-gen-go/descriptor"
+	"github.com/xeipuuv/gojsonschema"
+)
+
+// protoc-gen-validate (PGV) annotates fields with (validate.rules) options. Rather than depending on PGV's generated
+// Go code, the rules are decoded from the encoded extension (the numbers below come from PGV's validate.proto):
+const (
+	pgvRulesFieldNumber = 1071 // validate.rules (extends google.protobuf.FieldOptions)
+
+	// FieldRules:
+	pgvFloatRules     = 1
+	pgvDoubleRules    = 2
+	pgvInt32Rules     = 3
+	pgvInt64Rules     = 4
+	pgvUInt32Rules    = 5
+	pgvUInt64Rules    = 6
+	pgvSInt32Rules    = 7
+	pgvSInt64Rules    = 8
+	pgvFixed32Rules   = 9
+	pgvFixed64Rules   = 10
+	pgvSFixed32Rules  = 11
+	pgvSFixed64Rules  = 12
+	pgvBoolRules      = 13
+	pgvStringRules    = 14
+	pgvBytesRules     = 15
+	pgvEnumRules      = 16
+	pgvMessageRules   = 17
+	pgvRepeatedRules  = 18
+	pgvMapRules       = 19
+	pgvAnyRules       = 20
+	pgvDurationRules  = 21
+	pgvTimestampRules = 22
+
+	// FloatRules, DoubleRules, Int32Rules etc (which all look the same):
+	pgvNumericConst = 1
+	pgvNumericLt    = 2
+	pgvNumericLte   = 3
+	pgvNumericGt    = 4
+	pgvNumericGte   = 5
+	pgvNumericIn    = 6
+	pgvNumericNotIn = 7
+
+	// BoolRules:
+	pgvBoolConst = 1
+
+	// StringRules:
+	pgvStringConst       = 1
+	pgvStringMinLen      = 2
+	pgvStringMaxLen      = 3
+	pgvStringPattern     = 6
+	pgvStringPrefix      = 7
+	pgvStringSuffix      = 8
+	pgvStringContains    = 9
+	pgvStringIn          = 10
+	pgvStringNotIn       = 11
+	pgvStringEmail       = 12
+	pgvStringHostname    = 13
+	pgvStringIP          = 14
+	pgvStringIPv4        = 15
+	pgvStringIPv6        = 16
+	pgvStringURI         = 17
+	pgvStringURIRef      = 18
+	pgvStringLen         = 19
+	pgvStringAddress     = 21
+	pgvStringUUID        = 22
+	pgvStringNotContains = 23
+	pgvStringStrict      = 25
+
+	// EnumRules:
+	pgvEnumConst       = 1
+	pgvEnumDefinedOnly = 2
+	pgvEnumIn          = 3
+	pgvEnumNotIn       = 4
+
+	// MessageRules:
+	pgvMessageRequired = 2
+
+	// RepeatedRules:
+	pgvRepeatedMinItems = 1
+	pgvRepeatedMaxItems = 2
+	pgvRepeatedUnique   = 3
+	pgvRepeatedItems    = 4
+
+	// MapRules:
+	pgvMapMinPairs = 1
+	pgvMapMaxPairs = 2
+
+	// AnyRules, DurationRules and TimestampRules:
+	pgvWellKnownRequired = 1
+)
+
+// PGV's "uuid" rule (any version, in either case):
+const pgvUUIDPattern = `^[0-9a-fA-F]{8}-[0-9a-fA-F]{4}-[0-9a-fA-F]{4}-[0-9a-fA-F]{4}-[0-9a-fA-F]{12}$`
+
+// Without an ExtensionType, proto.GetExtension returns the encoded extension:
+var pgvRulesExtension = &proto.ExtensionDesc{Field: pgvRulesFieldNumber}
+
+// Reads the (validate.rules) options of a field, as the encoded fields of a PGV FieldRules message:
+func getPGVRules(desc *descriptor.FieldDescriptorProto) ([]wireField, error) {
+	if !proto.HasExtension(desc.GetOptions(), pgvRulesExtension) {
+		return nil, nil
+	}
+	extension, err := proto.GetExtension(desc.GetOptions(), pgvRulesExtension)
+	if err != nil {
+		return nil, err
+	}
+	encodedExtensions, err := decodeWireFields(extension.([]byte))
+	if err != nil {
+		return nil, err
+	}
+
+	// The extension can appear more than once (in which case the rules get merged):
+	var rules []wireField
+	for _, encodedExtension := range encodedExtensions {
+		extensionRules, err := decodeWireFields(encodedExtension.bytes)
+		if err != nil {
+			return nil, err
+		}
+		rules = append(rules, extensionRules...)
+	}
+	return rules, nil
+}
+
+// Translates the (validate.rules) options of a field into JSON-Schema keywords, returning whether they make it required:
+func applyPGVRules(jsonSchemaType *jsonschema.Type, desc *descriptor.FieldDescriptorProto) (bool, error) {
+	rules, err := getPGVRules(desc)
+	if err != nil {
+		return false, fmt.Errorf("unable to read (validate.rules) options of %s: %v", desc.GetName(), err)
+	}
+	return applyPGVFieldRules(jsonSchemaType, desc, rules)
+}
+
+func applyPGVFieldRules(jsonSchemaType *jsonschema.Type, desc *descriptor.FieldDescriptorProto, rules []wireField) (bool, error) {
+	required := false
+	for _, rule := range rules {
+		typeRules, err := decodeWireFields(rule.bytes)
+		if err != nil {
+			return false, fmt.Errorf("unable to decode (validate.rules) options of %s: %v", desc.GetName(), err)
+		}
+
+		switch rule.number {
+		case pgvFloatRules, pgvDoubleRules,
+			pgvInt32Rules, pgvInt64Rules,
+			pgvUInt32Rules, pgvUInt64Rules,
+			pgvSInt32Rules, pgvSInt64Rules,
+			pgvFixed32Rules, pgvFixed64Rules,
+			pgvSFixed32Rules, pgvSFixed64Rules:
+			applyPGVNumericRules(valueSchema(jsonSchemaType), desc, rule.number, typeRules)
+
+		case pgvBoolRules:
+			applyPGVBoolRules(valueSchema(jsonSchemaType), desc, typeRules)
+
+		case pgvStringRules:
+			applyPGVStringRules(valueSchema(jsonSchemaType), desc, typeRules)
+
+		case pgvEnumRules:
+			applyPGVEnumRules(valueSchema(jsonSchemaType), desc, typeRules)
+
+		case pgvMessageRules:
+			required = applyPGVRequiredRule(desc, "message", pgvMessageRequired, typeRules) || required
+
+		case pgvRepeatedRules:
+			if err := applyPGVRepeatedRules(jsonSchemaType, desc, typeRules); err != nil {
+				return false, err
+			}
+
+		case pgvMapRules:
+			applyPGVMapRules(jsonSchemaType, desc, typeRules)
+
+		case pgvAnyRules:
+			required = applyPGVRequiredRule(desc, "any", pgvWellKnownRequired, typeRules) || required
+
+		case pgvDurationRules:
+			required = applyPGVRequiredRule(desc, "duration", pgvWellKnownRequired, typeRules) || required
+
+		case pgvTimestampRules:
+			required = applyPGVRequiredRule(desc, "timestamp", pgvWellKnownRequired, typeRules) || required
+
+		case pgvBytesRules:
+			logWithLevel(LOG_WARN, "Ignoring PGV bytes rules on field %s (they have no JSON-Schema equivalent)", desc.GetName())
+
+		default:
+			logWithLevel(LOG_WARN, "Ignoring PGV rules #%d on field %s (they have no JSON-Schema equivalent)", rule.number, desc.GetName())
+		}
+	}
+	return required, nil
+}
+
+func warnUnsupportedPGVRule(desc *descriptor.FieldDescriptorProto, rulesName string, rule wireField) {
+	logWithLevel(LOG_WARN, "Ignoring PGV rule (validate.rules).%s #%d on field %s (it has no JSON-Schema equivalent)", rulesName, rule.number, desc.GetName())
+}
+
+// Translates the rules for any of the numeric types (which only differ in how their values are encoded):
+// Bounds can only be checked for numbers, so they don't apply to 64-bit integers written as strings:
+func applyPGVNumericRules(jsonSchemaType *jsonschema.Type, desc *descriptor.FieldDescriptorProto, rulesType int32, rules []wireField) {
+	// 64-bit integers can also be strings (which is how protojson writes them), so the allowed values can be too:
+	allowStrings := pgv64BitIntegerRules[rulesType] && hasStringForm(jsonSchemaType)
+
+	var in, notIn []interface{}
+	bounds := &jsonschema.Type{}
+	var lowerBound, upperBound json.Number
+	for _, rule := range rules {
+		values, err := decodePGVNumbers(rulesType, rule)
+		if err != nil || len(values) == 0 {
+			logWithLevel(LOG_WARN, "Unable to decode PGV rule #%d on field %s: %v", rule.number, desc.GetName(), err)
+			continue
+		}
+
+		switch rule.number {
+		case pgvNumericConst:
+			if allowStrings {
+				jsonSchemaType.Enum = pgvNumericValues(values[:1], allowStrings)
+			} else {
+				jsonSchemaType.Const = values[0]
+			}
+		case pgvNumericLt:
+			bounds.ExclusiveMaximum, upperBound = values[0], values[0]
+		case pgvNumericLte:
+			bounds.Maximum, upperBound = values[0], values[0]
+		case pgvNumericGt:
+			bounds.ExclusiveMinimum, lowerBound = values[0], values[0]
+		case pgvNumericGte:
+			bounds.Minimum, lowerBound = values[0], values[0]
+		case pgvNumericIn:
+			in = append(in, pgvNumericValues(values, allowStrings)...)
+		case pgvNumericNotIn:
+			notIn = append(notIn, pgvNumericValues(values, allowStrings)...)
+		default:
+			warnUnsupportedPGVRule(desc, "numeric", rule)
+		}
+	}
+
+	if len(in) > 0 {
+		jsonSchemaType.Enum = in
+	}
+	if len(notIn) > 0 {
+		jsonSchemaType.AllOf = append(jsonSchemaType.AllOf, &jsonschema.Type{Not: &jsonschema.Type{Enum: notIn}})
+	}
+
+	// PGV treats a lower bound above the upper bound as an exclusive range (the value has to be outside of it):
+	if lowerBound != "" && upperBound != "" && pgvFloat(lowerBound) > pgvFloat(upperBound) {
+		jsonSchemaType.AnyOf = append(jsonSchemaType.AnyOf,
+			&jsonschema.Type{Minimum: bounds.Minimum, ExclusiveMinimum: bounds.ExclusiveMinimum},
+			&jsonschema.Type{Maximum: bounds.Maximum, ExclusiveMaximum: bounds.ExclusiveMaximum},
+		)
+	} else {
+		if lowerBound != "" {
+			jsonSchemaType.Minimum, jsonSchemaType.ExclusiveMinimum = bounds.Minimum, bounds.ExclusiveMinimum
+		}
+		if upperBound != "" {
+			jsonSchemaType.Maximum, jsonSchemaType.ExclusiveMaximum = bounds.Maximum, bounds.ExclusiveMaximum
+		}
+	}
+}
+
+// The rules for 64-bit integers:
+var pgv64BitIntegerRules = map[int32]bool{
+	pgvInt64Rules:    true,
+	pgvUInt64Rules:   true,
+	pgvSInt64Rules:   true,
+	pgvFixed64Rules:  true,
+	pgvSFixed64Rules: true,
+}
+
+// Whether a JSON-Schema allows strings as one of its types:
+func hasStringForm(jsonSchemaType *jsonschema.Type) bool {
+	for _, oneOfJSONSchemaType := range jsonSchemaType.OneOf {
+		if oneOfJSONSchemaType.Type == gojsonschema.TYPE_STRING {
+			return true
+		}
+	}
+	return false
+}
+
+// Returns the JSON values of numbers (each followed by its string form, if strings are allowed):
+func pgvNumericValues(numbers []json.Number, allowStrings bool) []interface{} {
+	var values []interface{}
+	for _, number := range numbers {
+		values = append(values, number)
+		if allowStrings {
+			values = append(values, number.String())
+		}
+	}
+	return values
+}
+
+// Decodes the value(s) of a numeric rule (repeated rules like "in" may be packed):
+func decodePGVNumbers(rulesType int32, rule wireField) ([]json.Number, error) {
+	if rule.wireType != proto.WireBytes {
+		value, err := pgvNumber(rulesType, rule.value)
+		return []json.Number{value}, err
+	}
+
+	var values []json.Number
+	for data := rule.bytes; len(data) > 0; {
+		var value uint64
+		switch rulesType {
+		case pgvFloatRules, pgvFixed32Rules, pgvSFixed32Rules:
+			if len(data) < 4 {
+				return nil, fmt.Errorf("truncated packed values")
+			}
+			value, data = uint64(binary.LittleEndian.Uint32(data)), data[4:]
+		case pgvDoubleRules, pgvFixed64Rules, pgvSFixed64Rules:
+			if len(data) < 8 {
+				return nil, fmt.Errorf("truncated packed values")
+			}
+			value, data = binary.LittleEndian.Uint64(data), data[8:]
+		default:
+			var n int
+			if value, n = proto.DecodeVarint(data); n == 0 {
+				return nil, fmt.Errorf("invalid packed values")
+			}
+			data = data[n:]
+		}
+		number, err := pgvNumber(rulesType, value)
+		if err != nil {
+			return nil, err
+		}
+		values = append(values, number)
+	}
+	return values, nil
+}
+
+// Interprets the raw value of a numeric rule:
+func pgvNumber(rulesType int32, value uint64) (json.Number, error) {
+	switch rulesType {
+	case pgvFloatRules:
+		return jsonschema.Float(float64(math.Float32frombits(uint32(value))), 32)
+	case pgvDoubleRules:
+		return jsonschema.Float(math.Float64frombits(value), 64)
+	case pgvInt32Rules, pgvInt64Rules, pgvSFixed64Rules:
+		return jsonschema.Int(int64(value)), nil
+	case pgvSFixed32Rules:
+		return jsonschema.Int(int64(int32(value))), nil
+	case pgvSInt32Rules, pgvSInt64Rules:
+		// Zig-zag encoded:
+		return jsonschema.Int(int64(value>>1) ^ -int64(value&1)), nil
+	default:
+		return jsonschema.Uint(value), nil
+	}
+}
+
+// Numbers are only compared to spot exclusive ranges, so the precision of a float64 is plenty:
+func pgvFloat(number json.Number) float64 {
+	value, _ := number.Float64()
+	return value
+}
+
+func applyPGVBoolRules(jsonSchemaType *jsonschema.Type, desc *descriptor.FieldDescriptorProto, rules []wireField) {
+	for _, rule := range rules {
+		switch rule.number {
+		case pgvBoolConst:
+			jsonSchemaType.Const = rule.value != 0
+		default:
+			warnUnsupportedPGVRule(desc, "bool", rule)
+		}
+	}
+}
+
+func applyPGVStringRules(jsonSchemaType *jsonschema.Type, desc *descriptor.FieldDescriptorProto, rules []wireField) {
+	var in, notIn []interface{}
+	for _, rule := range rules {
+		switch rule.number {
+		case pgvStringConst:
+			jsonSchemaType.Const = string(rule.bytes)
+		case pgvStringLen:
+			jsonSchemaType.MinLength = jsonschema.Count(rule.value)
+			jsonSchemaType.MaxLength = jsonschema.Count(rule.value)
+		case pgvStringMinLen:
+			jsonSchemaType.MinLength = jsonschema.Count(rule.value)
+		case pgvStringMaxLen:
+			jsonSchemaType.MaxLength = jsonschema.Count(rule.value)
+		case pgvStringPattern:
+			addPattern(jsonSchemaType, string(rule.bytes))
+		case pgvStringPrefix:
+			addPattern(jsonSchemaType, "^"+regexp.QuoteMeta(string(rule.bytes)))
+		case pgvStringSuffix:
+			addPattern(jsonSchemaType, regexp.QuoteMeta(string(rule.bytes))+"$")
+		case pgvStringContains:
+			addPattern(jsonSchemaType, regexp.QuoteMeta(string(rule.bytes)))
+		case pgvStringNotContains:
+			jsonSchemaType.AllOf = append(jsonSchemaType.AllOf, &jsonschema.Type{
+				Not: &jsonschema.Type{Pattern: regexp.QuoteMeta(string(rule.bytes))},
+			})
+		case pgvStringIn:
+			in = append(in, string(rule.bytes))
+		case pgvStringNotIn:
+			notIn = append(notIn, string(rule.bytes))
+		case pgvStringEmail:
+			setPGVFormat(jsonSchemaType, rule, "email")
+		case pgvStringHostname:
+			setPGVFormat(jsonSchemaType, rule, "hostname")
+		case pgvStringIPv4:
+			setPGVFormat(jsonSchemaType, rule, "ipv4")
+		case pgvStringIPv6:
+			setPGVFormat(jsonSchemaType, rule, "ipv6")
+		case pgvStringURI:
+			setPGVFormat(jsonSchemaType, rule, "uri")
+		case pgvStringURIRef:
+			setPGVFormat(jsonSchemaType, rule, "uri-reference")
+		case pgvStringIP:
+			if rule.value != 0 {
+				jsonSchemaType.AnyOf = append(jsonSchemaType.AnyOf, &jsonschema.Type{Format: "ipv4"}, &jsonschema.Type{Format: "ipv6"})
+			}
+		case pgvStringAddress:
+			if rule.value != 0 {
+				jsonSchemaType.AnyOf = append(jsonSchemaType.AnyOf, &jsonschema.Type{Format: "hostname"}, &jsonschema.Type{Format: "ipv4"}, &jsonschema.Type{Format: "ipv6"})
+			}
+		case pgvStringUUID:
+			if rule.value != 0 {
+				addPattern(jsonSchemaType, pgvUUIDPattern)
+			}
+		case pgvStringStrict:
+			// Only affects the "well_known_regex" rule (which isn't supported)
+		default:
+			warnUnsupportedPGVRule(desc, "string", rule)
+		}
+	}
+
+	if len(in) > 0 {
+		jsonSchemaType.Enum = in
+	}
+	if len(notIn) > 0 {
+		jsonSchemaType.AllOf = append(jsonSchemaType.AllOf, &jsonschema.Type{Not: &jsonschema.Type{Enum: notIn}})
+	}
+}
+
+func setPGVFormat(jsonSchemaType *jsonschema.Type, rule wireField, format string) {
+	if rule.value != 0 {
+		jsonSchemaType.Format = format
+	}
+}
+
+// Adds a pattern to a JSON-Schema (which can only have one of its own, so any others have to be combined with allOf):
+func addPattern(jsonSchemaType *jsonschema.Type, pattern string) {
+	if jsonSchemaType.Pattern == "" {
+		jsonSchemaType.Pattern = pattern
+	} else {
+		jsonSchemaType.AllOf = append(jsonSchemaType.AllOf, &jsonschema.Type{Pattern: pattern})
+	}
+}
+
+// Enum rules are given as numbers, which are allowed by name (and by number, unless disallow_enum_one_of is set):
+func applyPGVEnumRules(jsonSchemaType *jsonschema.Type, desc *descriptor.FieldDescriptorProto, rules []wireField) {
+	enum, ok := globalPkg.lookupEnum(desc.GetTypeName())
+	if !ok {
+		logWithLevel(LOG_WARN, "Ignoring PGV enum rules on field %s (could not find enum %s)", desc.GetName(), desc.GetTypeName())
+		return
+	}
+
+	var in, notIn []interface{}
+	for _, rule := range rules {
+		switch rule.number {
+		case pgvEnumDefinedOnly:
+			// Enums only allow their defined values anyway
+		case pgvEnumConst, pgvEnumIn, pgvEnumNotIn:
+			numbers, err := decodePGVNumbers(pgvInt32Rules, rule)
+			if err != nil {
+				logWithLevel(LOG_WARN, "Unable to decode PGV rule #%d on field %s: %v", rule.number, desc.GetName(), err)
+				continue
+			}
+			values := pgvEnumValues(enum, numbers)
+			switch {
+			case rule.number == pgvEnumIn:
+				in = append(in, values...)
+			case rule.number == pgvEnumNotIn:
+				notIn = append(notIn, values...)
+			case len(values) == 1:
+				jsonSchemaType.Const = values[0]
+			default:
+				// Allowed by name and by number:
+				jsonSchemaType.Enum = values
+			}
+		default:
+			warnUnsupportedPGVRule(desc, "enum", rule)
+		}
+	}
+
+	if len(in) > 0 {
+		jsonSchemaType.Enum = in
+	}
+	if len(notIn) > 0 {
+		jsonSchemaType.AllOf = append(jsonSchemaType.AllOf, &jsonschema.Type{Not: &jsonschema.Type{Enum: notIn}})
+	}
+}
+
+// Returns the JSON values of an enum's values with the given numbers (in the order they're declared in):
+func pgvEnumValues(enum *descriptor.EnumDescriptorProto, numbers []json.Number) []interface{} {
+	var values []interface{}
+	for _, enumValue := range enum.GetValue() {
+		for _, number := range numbers {
+			if jsonschema.Int(int64(enumValue.GetNumber())) != number {
+				continue
+			}
+			values = append(values, enumValue.GetName())
+			if !disallowEnumOneOf {
+				values = append(values, enumValue.GetNumber())
+			}
+			break
+		}
+	}
+	return values
+}
+
+// Whether the "required" rule is set (for messages and the well-known types):
+func applyPGVRequiredRule(desc *descriptor.FieldDescriptorProto, rulesName string, requiredRule int32, rules []wireField) bool {
+	required := false
+	for _, rule := range rules {
+		if rule.number == requiredRule {
+			required = rule.value != 0
+		} else {
+			warnUnsupportedPGVRule(desc, rulesName, rule)
+		}
+	}
+	return required
+}
+
+func applyPGVRepeatedRules(jsonSchemaType *jsonschema.Type, desc *descriptor.FieldDescriptorProto, rules []wireField) error {
+	for _, rule := range rules {
+		switch rule.number {
+		case pgvRepeatedMinItems:
+			jsonSchemaType.MinItems = jsonschema.Count(rule.value)
+		case pgvRepeatedMaxItems:
+			jsonSchemaType.MaxItems = jsonschema.Count(rule.value)
+		case pgvRepeatedUnique:
+			jsonSchemaType.UniqueItems = rule.value != 0
+		case pgvRepeatedItems:
+			itemRules, err := decodeWireFields(rule.bytes)
+			if err != nil {
+				return fmt.Errorf("unable to decode (validate.rules) options of %s: %v", desc.GetName(), err)
+			}
+			if _, err := applyPGVFieldRules(valueSchema(jsonSchemaType), desc, itemRules); err != nil {
+				return err
+			}
+		default:
+			warnUnsupportedPGVRule(desc, "repeated", rule)
+		}
+	}
+	return nil
+}
+
+func applyPGVMapRules(jsonSchemaType *jsonschema.Type, desc *descriptor.FieldDescriptorProto, rules []wireField) {
+	for _, rule := range rules {
+		switch rule.number {
+		case pgvMapMinPairs:
+			jsonSchemaType.MinProperties = jsonschema.Count(rule.value)
+		case pgvMapMaxPairs:
+			jsonSchemaType.MaxProperties = jsonschema.Count(rule.value)
+		default:
+			warnUnsupportedPGVRule(desc, "map", rule)
+		}
+	}
+}
@@ -0,0 +1,73 @@
+package main
+
+import (
+	"fmt"
+
+	"github.com/RedVentures/protoc-gen-jsonschema/internal/jsonschema"
+	"github.com/golang/protobuf/protoc-gen-go/descriptor"
+)
+
+// propertyNaming is how properties are named (set with the proto_names parameter).
+type propertyNaming int
+
+const (
+	propertyNamesJSON  propertyNaming = iota // The lowerCamelCase json_name (the default, like protojson)
+	propertyNamesProto                       // The proto field name (like protojson with UseProtoNames)
+	propertyNamesBoth                        // Either of them (but not both at once)
+)
+
+func (n propertyNaming) String() string {
+	switch n {
+	case propertyNamesProto:
+		return "true"
+	case propertyNamesBoth:
+		return "both"
+	default:
+		return "false"
+	}
+}
+
+// Set parses a property naming (this makes it a flag.Value, which can be used like a boolean flag too).
+func (n *propertyNaming) Set(value string) error {
+	switch value {
+	case "", "true":
+		*n = propertyNamesProto
+	case "false":
+		*n = propertyNamesJSON
+	case "both":
+		*n = propertyNamesBoth
+	default:
+		return fmt.Errorf("unsupported value for proto_names %q (expected true, false or both)", value)
+	}
+	return nil
+}
+
+// IsBoolFlag lets "-proto_names" be given without a value.
+func (n *propertyNaming) IsBoolFlag() bool {
+	return true
+}
+
+// Returns the names a field's property can have:
+func propertyNamesFor(desc *descriptor.FieldDescriptorProto) []string {
+	switch propertyNames {
+	case propertyNamesProto:
+		return []string{desc.GetName()}
+	case propertyNamesBoth:
+		if desc.GetJsonName() != desc.GetName() {
+			return []string{desc.GetJsonName(), desc.GetName()}
+		}
+	}
+	return []string{desc.GetJsonName()}
+}
+
+// Returns a JSON-Schema which requires a field's property to be present (under any of its names):
+func requiredProperty(names []string) *jsonschema.Type {
+	if len(names) == 1 {
+		return &jsonschema.Type{Required: names}
+	}
+	jsonSchemaType := &jsonschema.Type{}
+	for _, name := range names {
+		jsonSchemaType.AnyOf = append(jsonSchemaType.AnyOf, &jsonschema.Type{Required: []string{name}})
+	}
+	return jsonSchemaType
+}
@@ -0,0 +1,51 @@
+package main
+
+import (
+	"fmt"
+	"sort"
+
+	"github.com/golang/protobuf/protoc-gen-go/descriptor"
+)
+
+// propertyOrdering is the order properties are written out in (set with the property_order parameter).
+type propertyOrdering int
+
+const (
+	propertyOrderAlphabetical propertyOrdering = iota // Sorted by name (the default)
+	propertyOrderFieldNumber                          // Sorted by proto field number
+	propertyOrderDeclaration                          // The order the fields were declared in
+)
+
+var propertyOrderNames = map[propertyOrdering]string{
+	propertyOrderAlphabetical: "alphabetical",
+	propertyOrderFieldNumber:  "field_number",
+	propertyOrderDeclaration:  "declaration",
+}
+
+func (o propertyOrdering) String() string {
+	return propertyOrderNames[o]
+}
+
+// Set parses a property order (this makes it a flag.Value).
+func (o *propertyOrdering) Set(name string) error {
+	for order, orderName := range propertyOrderNames {
+		if orderName == name {
+			*o = order
+			return nil
+		}
+	}
+	return fmt.Errorf("unsupported property order %q (expected alphabetical, field_number or declaration)", name)
+}
+
+// Returns the fields of a message in the order their properties should be written out in (alphabetical
+// order is left to the encoder, so those stay in declaration order):
+func orderedFields(msg *descriptor.DescriptorProto) []*descriptor.FieldDescriptorProto {
+	fields := msg.GetField()
+	if propertyOrder == propertyOrderFieldNumber {
+		fields = append([]*descriptor.FieldDescriptorProto{}, fields...)
+		sort.SliceStable(fields, func(i, j int) bool {
+			return fields[i].GetNumber() < fields[j].GetNumber()
+		})
+	}
+	return fields
+}
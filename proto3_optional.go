@@ -0,0 +1,66 @@
+package main
+
+import (
+	"github.com/golang/protobuf/proto"
+	"github.com/golang/protobuf/protoc-gen-go/descriptor"
+	plugin "github.com/golang/protobuf/protoc-gen-go/plugin"
+)
+
+// The vendored golang/protobuf predates proto3 "optional" fields, so the descriptor and plugin fields which
+// describe them are read from (and written to) the unrecognised fields of the generated structs directly.
+const (
+	fieldDescriptorProto3OptionalFieldNumber          = 17 // FieldDescriptorProto.proto3_optional
+	codeGeneratorResponseSupportedFeaturesFieldNumber = 2  // CodeGeneratorResponse.supported_features
+	featureProto3Optional                             = 1  // CodeGeneratorResponse.Feature.FEATURE_PROTO3_OPTIONAL
+)
+
+// Tells protoc which optional features of the plugin protocol we support:
+func setSupportedFeatures(res *plugin.CodeGeneratorResponse) {
+	buf := proto.NewBuffer(nil)
+	buf.EncodeVarint(uint64(codeGeneratorResponseSupportedFeaturesFieldNumber<<3 | proto.WireVarint))
+	buf.EncodeVarint(featureProto3Optional)
+	res.XXX_unrecognized = append(res.XXX_unrecognized, buf.Bytes()...)
+}
+
+// Whether a field was declared with the proto3 "optional" label:
+func isProto3Optional(desc *descriptor.FieldDescriptorProto) bool {
+	fields, err := decodeWireFields(desc.XXX_unrecognized)
+	if err != nil {
+		logWithLevel(LOG_WARN, "unable to decode unrecognised fields of %s: %v", desc.GetName(), err)
+		return false
+	}
+	for _, field := range fields {
+		if field.number == fieldDescriptorProto3OptionalFieldNumber && field.wireType == proto.WireVarint {
+			return field.value != 0
+		}
+	}
+	return false
+}
+
+// Whether a oneof was made up by protoc to hold a proto3 "optional" field (rather than being declared in the proto):
+func isSyntheticOneOf(msg *descriptor.DescriptorProto, oneOfIndex int) bool {
+	for _, fieldDesc := range msg.GetField() {
+		if fieldDesc.OneofIndex != nil && int(fieldDesc.GetOneofIndex()) == oneOfIndex {
+			return isProto3Optional(fieldDesc)
+		}
+	}
+	return false
+}
+
+// Whether a field tracks presence explicitly (so a NULL value can mean "not set"). This is the case for
+// singular message fields, members of a oneof (including proto3 "optional" fields), and all proto2 fields. The fields
+// of map entries never do (protojson rejects NULL map values):
+func hasExplicitPresence(desc *descriptor.FieldDescriptorProto, msg *descriptor.DescriptorProto) bool {
+	switch {
+	case desc.GetLabel() == descriptor.FieldDescriptorProto_LABEL_REPEATED,
+		msg.GetOptions().GetMapEntry():
+		return false
+	case desc.GetType() == descriptor.FieldDescriptorProto_TYPE_MESSAGE,
+		desc.GetType() == descriptor.FieldDescriptorProto_TYPE_GROUP:
+		return true
+	case desc.OneofIndex != nil:
+		return true
+	default:
+		return messageSyntaxes[msg] != "proto3"
+	}
+}
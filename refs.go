@@ -0,0 +1,113 @@
+package main
+
+import (
+	"fmt"
+	"path"
+	"path/filepath"
+	"strings"
+
+	"github.com/golang/protobuf/protoc-gen-go/descriptor"
+)
+
+// externalRefMode is how messages and enums from other proto files are referred to (set with the external_refs parameter).
+type externalRefMode int
+
+const (
+	externalRefsNone     externalRefMode = iota // They are copied in (the default)
+	externalRefsRelative                        // With a "$ref" to the file generated for them, relative to the referring file
+	externalRefsAbsolute                        // With a "$ref" to the file generated for them, under the base_uri
+)
+
+var externalRefModeNames = map[externalRefMode]string{
+	externalRefsNone:     "none",
+	externalRefsRelative: "relative",
+	externalRefsAbsolute: "absolute",
+}
+
+func (m externalRefMode) String() string {
+	return externalRefModeNames[m]
+}
+
+// Set parses an external ref mode (this makes it a flag.Value).
+func (m *externalRefMode) Set(name string) error {
+	for mode, modeName := range externalRefModeNames {
+		if modeName == name {
+			*m = mode
+			return nil
+		}
+	}
+	return fmt.Errorf("unsupported external refs %q (expected none, relative or absolute)", name)
+}
+
+// A declaration is where a message or enum was declared:
+type declaration struct {
+	file     *descriptor.FileDescriptorProto
+	typeName string // Path of the message or enum within the file (eg "Outer.Inner")
+	nested   bool
+}
+
+// Every message and enum we've seen, by fully qualified name (eg ".samples.Outer.Inner"):
+var declarations = make(map[string]declaration)
+
+// Registers the declarations of all the messages and enums in a proto file:
+func registerDeclarations(file *descriptor.FileDescriptorProto) {
+	prefix := "."
+	if file.GetPackage() != "" {
+		prefix = "." + file.GetPackage() + "."
+	}
+	for _, enum := range file.GetEnumType() {
+		declarations[prefix+enum.GetName()] = declaration{file: file, typeName: enum.GetName()}
+	}
+	for _, msg := range file.GetMessageType() {
+		registerMessageDeclarations(file, prefix, msg.GetName(), msg, false)
+	}
+}
+
+func registerMessageDeclarations(file *descriptor.FileDescriptorProto, prefix string, typeName string, msg *descriptor.DescriptorProto, nested bool) {
+	declarations[prefix+typeName] = declaration{file: file, typeName: typeName, nested: nested}
+	for _, enum := range msg.GetEnumType() {
+		declarations[prefix+typeName+"."+enum.GetName()] = declaration{file: file, typeName: typeName + "." + enum.GetName(), nested: true}
+	}
+	for _, nestedMsg := range msg.GetNestedType() {
+		registerMessageDeclarations(file, prefix, typeName+"."+nestedMsg.GetName(), nestedMsg, true)
+	}
+}
+
+// The proto files being generated in this run (by name), which are the only ones that can be referenced:
+var generatedProtoFiles = make(map[string]bool)
+
+func registerGeneratedFiles(files []*descriptor.FileDescriptorProto) {
+	generatedProtoFiles = make(map[string]bool)
+	for _, file := range files {
+		generatedProtoFiles[file.GetName()] = true
+	}
+}
+
+// Returns the URI of an output file (under the base_uri):
+func schemaURI(jsonSchemaFileName string) string {
+	return strings.TrimSuffix(baseURI, "/") + "/" + jsonSchemaFileName
+}
+
+// Returns a "$ref" to the file generated for a message or enum, if it comes from a different proto file than the one
+// being converted (and has a file of its own in this run, which means its proto file is being generated too, and nested
+// types only have one with generate_nested_types). Anything else is copied in as before:
+func (s *conversionState) externalRef(typeName string) (string, bool) {
+	if externalRefs == externalRefsNone || s.file == nil {
+		return "", false
+	}
+	decl, ok := declarations[typeName]
+	if !ok || decl.file.GetName() == s.file.GetName() || !generatedProtoFiles[decl.file.GetName()] || (decl.nested && !generateNestedTypes) {
+		return "", false
+	}
+
+	jsonSchemaFileName := outputFileName(decl.file, decl.typeName)
+	if externalRefs == externalRefsAbsolute {
+		return schemaURI(jsonSchemaFileName), true
+	}
+	relativeFileName, err := filepath.Rel(filepath.FromSlash(path.Dir(s.outputFileName)), filepath.FromSlash(jsonSchemaFileName))
+	if err != nil {
+		logWithLevel(LOG_WARN, "Unable to reference %s from %s: %v", jsonSchemaFileName, s.outputFileName, err)
+		return "", false
+	}
+	return filepath.ToSlash(relativeFileName), true
+}
@@ -14,6 +14,8 @@ const ArrayOfMessages = `{
                         "type": "boolean"
                     },
                     "id": {
+                        "maximum": 2147483647,
+                        "minimum": -2147483648,
                         "type": "integer"
                     },
                     "name": {
@@ -4,68 +4,28 @@ const ArrayOfObjects = `{
     "$schema": "http://json-schema.org/draft-04/schema#",
     "properties": {
         "description": {
-            "oneOf": [
-                {
-                    "type": "null"
-                },
-                {
-                    "type": "string"
-                }
-            ]
+            "type": "string"
         },
         "payload": {
             "items": {
                 "$schema": "http://json-schema.org/draft-04/schema#",
                 "properties": {
                     "complete": {
-                        "oneOf": [
-                            {
-                                "type": "null"
-                            },
-                            {
-                                "type": "boolean"
-                            }
-                        ]
+                        "type": "boolean"
                     },
                     "id": {
-                        "oneOf": [
-                            {
-                                "type": "null"
-                            },
-                            {
-                                "type": "integer"
-                            }
-                        ]
+                        "maximum": 2147483647,
+                        "minimum": -2147483648,
+                        "type": "integer"
                     },
                     "name": {
-                        "oneOf": [
-                            {
-                                "type": "null"
-                            },
-                            {
-                                "type": "string"
-                            }
-                        ]
+                        "type": "string"
                     },
                     "rating": {
-                        "oneOf": [
-                            {
-                                "type": "null"
-                            },
-                            {
-                                "type": "number"
-                            }
-                        ]
+                        "type": "number"
                     },
                     "timestamp": {
-                        "oneOf": [
-                            {
-                                "type": "null"
-                            },
-                            {
-                                "type": "string"
-                            }
-                        ]
+                        "type": "string"
                     },
                     "topology": {
                         "enum": [
@@ -88,9 +48,6 @@ const ArrayOfObjects = `{
                             },
                             {
                                 "type": "integer"
-                            },
-                            {
-                                "type": "null"
                             }
                         ]
                     }
@@ -105,14 +62,7 @@ const ArrayOfObjects = `{
                     }
                 ]
             },
-            "oneOf": [
-                {
-                    "type": "null"
-                },
-                {
-                    "type": "array"
-                }
-            ]
+            "type": "array"
         }
     },
     "additionalProperties": true,
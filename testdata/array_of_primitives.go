@@ -4,77 +4,37 @@ const ArrayOfPrimitives = `{
     "$schema": "http://json-schema.org/draft-04/schema#",
     "properties": {
         "description": {
-            "oneOf": [
-                {
-                    "type": "null"
-                },
-                {
-                    "type": "string"
-                }
-            ]
+            "type": "string"
         },
         "keyWords": {
             "items": {
-                "oneOf": [
-                    {
-                        "type": "null"
-                    },
-                    {
-                        "type": "string"
-                    }
-                ]
+                "type": "string"
             },
-            "oneOf": [
-                {
-                    "type": "null"
-                },
-                {
-                    "type": "array"
-                }
-            ]
+            "type": "array"
         },
         "luckyBigNumbers": {
             "items": {
                 "oneOf": [
                     {
+                        "maximum": 9223372036854775807,
+                        "minimum": -9223372036854775808,
                         "type": "integer"
                     },
                     {
+                        "pattern": "^-?[0-9]+$",
                         "type": "string"
-                    },
-                    {
-                        "type": "null"
                     }
                 ]
             },
-            "oneOf": [
-                {
-                    "type": "null"
-                },
-                {
-                    "type": "array"
-                }
-            ]
+            "type": "array"
         },
         "luckyNumbers": {
             "items": {
-                "oneOf": [
-                    {
-                        "type": "null"
-                    },
-                    {
-                        "type": "integer"
-                    }
-                ]
+                "maximum": 2147483647,
+                "minimum": -2147483648,
+                "type": "integer"
             },
-            "oneOf": [
-                {
-                    "type": "null"
-                },
-                {
-                    "type": "array"
-                }
-            ]
+            "type": "array"
         }
     },
     "additionalProperties": true,
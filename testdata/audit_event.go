@@ -0,0 +1,15 @@
+package testdata
+
+const AuditEvent = `{
+    "$schema": "http://json-schema.org/draft-04/schema#",
+    "properties": {
+        "action": {
+            "type": "string"
+        },
+        "actor": {
+            "type": "string"
+        }
+    },
+    "additionalProperties": true,
+    "type": "object"
+}`
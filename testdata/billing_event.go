@@ -0,0 +1,17 @@
+package testdata
+
+const BillingEvent = `{
+    "$schema": "http://json-schema.org/draft-04/schema#",
+    "properties": {
+        "amount": {
+            "maximum": 2147483647,
+            "minimum": -2147483648,
+            "type": "integer"
+        },
+        "invoiceId": {
+            "type": "string"
+        }
+    },
+    "additionalProperties": true,
+    "type": "object"
+}`
@@ -0,0 +1,24 @@
+package testdata
+
+const BundleFileAuditEvent = `{
+    "$schema": "http://json-schema.org/draft-04/schema#",
+    "anyOf": [
+        {
+            "$ref": "#/definitions/audit.v2.Event"
+        }
+    ],
+    "definitions": {
+        "audit.v2.Event": {
+            "properties": {
+                "action": {
+                    "type": "string"
+                },
+                "actor": {
+                    "type": "string"
+                }
+            },
+            "additionalProperties": true,
+            "type": "object"
+        }
+    }
+}`
@@ -0,0 +1,26 @@
+package testdata
+
+const BundleFileBillingEvent = `{
+    "$schema": "http://json-schema.org/draft-04/schema#",
+    "anyOf": [
+        {
+            "$ref": "#/definitions/billing.v1.Event"
+        }
+    ],
+    "definitions": {
+        "billing.v1.Event": {
+            "properties": {
+                "amount": {
+                    "maximum": 2147483647,
+                    "minimum": -2147483648,
+                    "type": "integer"
+                },
+                "invoiceId": {
+                    "type": "string"
+                }
+            },
+            "additionalProperties": true,
+            "type": "object"
+        }
+    }
+}`
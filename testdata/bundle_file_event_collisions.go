@@ -0,0 +1,50 @@
+package testdata
+
+const BundleFileEventCollisions = `{
+    "$schema": "http://json-schema.org/draft-04/schema#",
+    "anyOf": [
+        {
+            "$ref": "#/definitions/samples.EventCollisions"
+        }
+    ],
+    "definitions": {
+        "audit.v2.Event": {
+            "properties": {
+                "action": {
+                    "type": "string"
+                },
+                "actor": {
+                    "type": "string"
+                }
+            },
+            "additionalProperties": true,
+            "type": "object"
+        },
+        "billing.v1.Event": {
+            "properties": {
+                "amount": {
+                    "maximum": 2147483647,
+                    "minimum": -2147483648,
+                    "type": "integer"
+                },
+                "invoiceId": {
+                    "type": "string"
+                }
+            },
+            "additionalProperties": true,
+            "type": "object"
+        },
+        "samples.EventCollisions": {
+            "properties": {
+                "auditEvent": {
+                    "$ref": "#/definitions/audit.v2.Event"
+                },
+                "billingEvent": {
+                    "$ref": "#/definitions/billing.v1.Event"
+                }
+            },
+            "additionalProperties": true,
+            "type": "object"
+        }
+    }
+}`
@@ -0,0 +1,80 @@
+package testdata
+
+const BundlePackage = `{
+    "$schema": "http://json-schema.org/draft-04/schema#",
+    "anyOf": [
+        {
+            "$ref": "#/definitions/samples.PayloadMessage"
+        },
+        {
+            "$ref": "#/definitions/samples.PayloadMessage.Topology"
+        },
+        {
+            "$ref": "#/definitions/samples.NestedMessage"
+        }
+    ],
+    "definitions": {
+        "samples.NestedMessage": {
+            "properties": {
+                "description": {
+                    "type": "string"
+                },
+                "payload": {
+                    "$ref": "#/definitions/samples.PayloadMessage"
+                }
+            },
+            "additionalProperties": true,
+            "type": "object"
+        },
+        "samples.PayloadMessage": {
+            "properties": {
+                "complete": {
+                    "type": "boolean"
+                },
+                "id": {
+                    "maximum": 2147483647,
+                    "minimum": -2147483648,
+                    "type": "integer"
+                },
+                "name": {
+                    "type": "string"
+                },
+                "rating": {
+                    "type": "number"
+                },
+                "timestamp": {
+                    "type": "string"
+                },
+                "topology": {
+                    "$ref": "#/definitions/samples.PayloadMessage.Topology"
+                }
+            },
+            "additionalProperties": true,
+            "type": "object"
+        },
+        "samples.PayloadMessage.Topology": {
+            "enum": [
+                "FLAT",
+                0,
+                "NESTED_OBJECT",
+                1,
+                "NESTED_MESSAGE",
+                2,
+                "ARRAY_OF_TYPE",
+                3,
+                "ARRAY_OF_OBJECT",
+                4,
+                "ARRAY_OF_MESSAGE",
+                5
+            ],
+            "oneOf": [
+                {
+                    "type": "string"
+                },
+                {
+                    "type": "integer"
+                }
+            ]
+        }
+    }
+}`
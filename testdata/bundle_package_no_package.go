@@ -0,0 +1,59 @@
+package testdata
+
+const BundlePackageNoPackage = `{
+    "$schema": "http://json-schema.org/draft-04/schema#",
+    "anyOf": [
+        {
+            "$ref": "#/definitions/Shape"
+        },
+        {
+            "$ref": "#/definitions/Canvas"
+        }
+    ],
+    "definitions": {
+        "Canvas": {
+            "properties": {
+                "origin": {
+                    "$ref": "#/definitions/Canvas.Point"
+                },
+                "shape": {
+                    "$ref": "#/definitions/Shape"
+                }
+            },
+            "additionalProperties": true,
+            "type": "object"
+        },
+        "Canvas.Point": {
+            "properties": {
+                "x": {
+                    "maximum": 2147483647,
+                    "minimum": -2147483648,
+                    "type": "integer"
+                },
+                "y": {
+                    "maximum": 2147483647,
+                    "minimum": -2147483648,
+                    "type": "integer"
+                }
+            },
+            "additionalProperties": true,
+            "type": "object"
+        },
+        "Shape": {
+            "enum": [
+                "CIRCLE",
+                0,
+                "SQUARE",
+                1
+            ],
+            "oneOf": [
+                {
+                    "type": "string"
+                },
+                {
+                    "type": "integer"
+                }
+            ]
+        }
+    }
+}`
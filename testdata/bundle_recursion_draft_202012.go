@@ -0,0 +1,81 @@
+package testdata
+
+const BundleRecursionDraft202012 = `{
+    "$schema": "https://json-schema.org/draft/2020-12/schema",
+    "anyOf": [
+        {
+            "$ref": "#/$defs/samples.Recursion"
+        }
+    ],
+    "$defs": {
+        "samples.Recursion": {
+            "properties": {
+                "graph": {
+                    "$ref": "#/$defs/samples.Recursion.Graph"
+                },
+                "parent": {
+                    "$ref": "#/$defs/samples.Recursion"
+                },
+                "ping": {
+                    "$ref": "#/$defs/samples.Recursion.Ping"
+                },
+                "tree": {
+                    "$ref": "#/$defs/samples.Recursion.Node"
+                }
+            },
+            "additionalProperties": true,
+            "type": "object"
+        },
+        "samples.Recursion.Graph": {
+            "properties": {
+                "neighbours": {
+                    "additionalProperties": {
+                        "$ref": "#/$defs/samples.Recursion.Graph"
+                    },
+                    "type": "object"
+                }
+            },
+            "additionalProperties": true,
+            "type": "object",
+            "title": "Recursion through a map:",
+            "description": "Recursion through a map:"
+        },
+        "samples.Recursion.Node": {
+            "properties": {
+                "children": {
+                    "items": {
+                        "$ref": "#/$defs/samples.Recursion.Node"
+                    },
+                    "type": "array"
+                },
+                "value": {
+                    "type": "string"
+                }
+            },
+            "additionalProperties": true,
+            "type": "object",
+            "title": "Direct recursion:",
+            "description": "Direct recursion:"
+        },
+        "samples.Recursion.Ping": {
+            "properties": {
+                "pong": {
+                    "$ref": "#/$defs/samples.Recursion.Pong"
+                }
+            },
+            "additionalProperties": true,
+            "type": "object",
+            "title": "Indirect recursion:",
+            "description": "Indirect recursion:"
+        },
+        "samples.Recursion.Pong": {
+            "properties": {
+                "ping": {
+                    "$ref": "#/$defs/samples.Recursion.Ping"
+                }
+            },
+            "additionalProperties": true,
+            "type": "object"
+        }
+    }
+}`
@@ -0,0 +1,42 @@
+package testdata
+
+const Bytes = `{
+    "$schema": "http://json-schema.org/draft-04/schema#",
+    "properties": {
+        "blobs": {
+            "additionalProperties": {
+                "pattern": "^((([A-Za-z0-9+/]{4})*([A-Za-z0-9+/]{2}(==)?|[A-Za-z0-9+/]{3}=?)?)|(([A-Za-z0-9_-]{4})*([A-Za-z0-9_-]{2}(==)?|[A-Za-z0-9_-]{3}=?)?))$",
+                "type": "string"
+            },
+            "type": "object"
+        },
+        "checksum": {
+            "pattern": "^((([A-Za-z0-9+/]{4})*([A-Za-z0-9+/]{2}(==)?|[A-Za-z0-9+/]{3}=?)?)|(([A-Za-z0-9_-]{4})*([A-Za-z0-9_-]{2}(==)?|[A-Za-z0-9_-]{3}=?)?))$",
+            "type": "string"
+        },
+        "chunks": {
+            "items": {
+                "pattern": "^((([A-Za-z0-9+/]{4})*([A-Za-z0-9+/]{2}(==)?|[A-Za-z0-9+/]{3}=?)?)|(([A-Za-z0-9_-]{4})*([A-Za-z0-9_-]{2}(==)?|[A-Za-z0-9_-]{3}=?)?))$",
+                "type": "string"
+            },
+            "type": "array"
+        },
+        "data": {
+            "pattern": "^((([A-Za-z0-9+/]{4})*([A-Za-z0-9+/]{2}(==)?|[A-Za-z0-9+/]{3}=?)?)|(([A-Za-z0-9_-]{4})*([A-Za-z0-9_-]{2}(==)?|[A-Za-z0-9_-]{3}=?)?))$",
+            "type": "string"
+        },
+        "wrapped": {
+            "oneOf": [
+                {
+                    "type": "null"
+                },
+                {
+                    "pattern": "^((([A-Za-z0-9+/]{4})*([A-Za-z0-9+/]{2}(==)?|[A-Za-z0-9+/]{3}=?)?)|(([A-Za-z0-9_-]{4})*([A-Za-z0-9_-]{2}(==)?|[A-Za-z0-9_-]{3}=?)?))$",
+                    "type": "string"
+                }
+            ]
+        }
+    },
+    "additionalProperties": true,
+    "type": "object"
+}`
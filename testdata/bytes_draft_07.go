@@ -0,0 +1,61 @@
+package testdata
+
+const BytesDraft07 = `{
+    "$schema": "http://json-schema.org/draft-07/schema#",
+    "properties": {
+        "blobs": {
+            "additionalProperties": {
+                "pattern": "^((([A-Za-z0-9+/]{4})*([A-Za-z0-9+/]{2}(==)?|[A-Za-z0-9+/]{3}=?)?)|(([A-Za-z0-9_-]{4})*([A-Za-z0-9_-]{2}(==)?|[A-Za-z0-9_-]{3}=?)?))$",
+                "type": "string",
+                "contentEncoding": "base64"
+            },
+            "type": "object"
+        },
+        "checksum": {
+            "oneOf": [
+                {
+                    "type": "null"
+                },
+                {
+                    "pattern": "^((([A-Za-z0-9+/]{4})*([A-Za-z0-9+/]{2}(==)?|[A-Za-z0-9+/]{3}=?)?)|(([A-Za-z0-9_-]{4})*([A-Za-z0-9_-]{2}(==)?|[A-Za-z0-9_-]{3}=?)?))$",
+                    "type": "string",
+                    "contentEncoding": "base64"
+                }
+            ]
+        },
+        "chunks": {
+            "items": {
+                "pattern": "^((([A-Za-z0-9+/]{4})*([A-Za-z0-9+/]{2}(==)?|[A-Za-z0-9+/]{3}=?)?)|(([A-Za-z0-9_-]{4})*([A-Za-z0-9_-]{2}(==)?|[A-Za-z0-9_-]{3}=?)?))$",
+                "type": "string",
+                "contentEncoding": "base64"
+            },
+            "type": "array"
+        },
+        "data": {
+            "pattern": "^((([A-Za-z0-9+/]{4})*([A-Za-z0-9+/]{2}(==)?|[A-Za-z0-9+/]{3}=?)?)|(([A-Za-z0-9_-]{4})*([A-Za-z0-9_-]{2}(==)?|[A-Za-z0-9_-]{3}=?)?))$",
+            "type": "string",
+            "contentEncoding": "base64"
+        },
+        "wrapped": {
+            "oneOf": [
+                {
+                    "type": "null"
+                },
+                {
+                    "pattern": "^((([A-Za-z0-9+/]{4})*([A-Za-z0-9+/]{2}(==)?|[A-Za-z0-9+/]{3}=?)?)|(([A-Za-z0-9_-]{4})*([A-Za-z0-9_-]{2}(==)?|[A-Za-z0-9_-]{3}=?)?))$",
+                    "type": "string",
+                    "contentEncoding": "base64"
+                }
+            ]
+        }
+    },
+    "additionalProperties": true,
+    "oneOf": [
+        {
+            "type": "null"
+        },
+        {
+            "type": "object"
+        }
+    ]
+}`
@@ -0,0 +1,41 @@
+package testdata
+
+const Canvas = `{
+    "$schema": "http://json-schema.org/draft-04/schema#",
+    "properties": {
+        "origin": {
+            "properties": {
+                "x": {
+                    "maximum": 2147483647,
+                    "minimum": -2147483648,
+                    "type": "integer"
+                },
+                "y": {
+                    "maximum": 2147483647,
+                    "minimum": -2147483648,
+                    "type": "integer"
+                }
+            },
+            "additionalProperties": true,
+            "type": "object"
+        },
+        "shape": {
+            "enum": [
+                "CIRCLE",
+                0,
+                "SQUARE",
+                1
+            ],
+            "oneOf": [
+                {
+                    "type": "string"
+                },
+                {
+                    "type": "integer"
+                }
+            ]
+        }
+    },
+    "additionalProperties": true,
+    "type": "object"
+}`
@@ -0,0 +1,47 @@
+package testdata
+
+const CommentsYAML = `$schema: "http://json-schema.org/draft-04/schema#"
+properties:
+  colour:
+    $ref: "#/definitions/samples.Comments.Colour"
+  count:
+    maximum: 2147483647
+    minimum: -2147483648
+    type: integer
+    title: "A trailing comment."
+    description: "A trailing comment."
+  name:
+    type: string
+    title: "A plain string field."
+    description: "A plain string field."
+  nested:
+    $ref: "#/definitions/samples.Comments.Nested"
+    title: "Something nested."
+    description: "Something nested."
+additionalProperties: true
+type: object
+definitions:
+  samples.Comments.Colour:
+    enum:
+      - RED
+      - 0
+      - GREEN
+      - 1
+      - BLUE
+      - 2
+    oneOf:
+      - type: string
+      - type: integer
+    title: "The colour of something."
+    description: "The colour of something.\n\nRED: The colour of blood\nGREEN: The colour of grass"
+  samples.Comments.Nested:
+    properties:
+      flag:
+        type: boolean
+        title: "A field of a nested message."
+        description: "A field of a nested message."
+    additionalProperties: true
+    type: object
+title: "A message with comments."
+description: "A message with comments.\nThis line (and the one above) ends up in the description."
+`
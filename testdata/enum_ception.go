@@ -23,6 +23,8 @@ const EnumCeption = `{
             ]
         },
         "id": {
+            "maximum": 2147483647,
+            "minimum": -2147483648,
             "type": "integer"
         },
         "importedEnum": {
@@ -54,6 +56,8 @@ const EnumCeption = `{
                     "type": "boolean"
                 },
                 "id": {
+                    "maximum": 2147483647,
+                    "minimum": -2147483648,
                     "type": "integer"
                 },
                 "name": {
@@ -101,6 +105,8 @@ const EnumCeption = `{
                         "type": "boolean"
                     },
                     "id": {
+                        "maximum": 2147483647,
+                        "minimum": -2147483648,
                         "type": "integer"
                     },
                     "name": {
@@ -0,0 +1,129 @@
+package testdata
+
+const EnumCeptionWithDefinitions = `{
+    "$schema": "http://json-schema.org/draft-04/schema#",
+    "properties": {
+        "complete": {
+            "type": "boolean"
+        },
+        "failureMode": {
+            "$ref": "#/definitions/samples.Enumception.FailureModes"
+        },
+        "id": {
+            "maximum": 2147483647,
+            "minimum": -2147483648,
+            "type": "integer"
+        },
+        "importedEnum": {
+            "$ref": "#/definitions/samples.ImportedEnum"
+        },
+        "name": {
+            "type": "string"
+        },
+        "payload": {
+            "$ref": "#/definitions/samples.PayloadMessage"
+        },
+        "payloads": {
+            "items": {
+                "$ref": "#/definitions/samples.PayloadMessage"
+            },
+            "type": "array"
+        },
+        "rating": {
+            "type": "number"
+        },
+        "timestamp": {
+            "type": "string"
+        }
+    },
+    "additionalProperties": true,
+    "type": "object",
+    "definitions": {
+        "samples.Enumception.FailureModes": {
+            "enum": [
+                "RECURSION_ERROR",
+                0,
+                "SYNTAX_ERROR",
+                1
+            ],
+            "oneOf": [
+                {
+                    "type": "string"
+                },
+                {
+                    "type": "integer"
+                }
+            ]
+        },
+        "samples.ImportedEnum": {
+            "enum": [
+                "VALUE_0",
+                0,
+                "VALUE_1",
+                1,
+                "VALUE_2",
+                2,
+                "VALUE_3",
+                3
+            ],
+            "oneOf": [
+                {
+                    "type": "string"
+                },
+                {
+                    "type": "integer"
+                }
+            ]
+        },
+        "samples.PayloadMessage": {
+            "properties": {
+                "complete": {
+                    "type": "boolean"
+                },
+                "id": {
+                    "maximum": 2147483647,
+                    "minimum": -2147483648,
+                    "type": "integer"
+                },
+                "name": {
+                    "type": "string"
+                },
+                "rating": {
+                    "type": "number"
+                },
+                "timestamp": {
+                    "type": "string"
+                },
+                "topology": {
+                    "$ref": "#/definitions/samples.PayloadMessage.Topology"
+                }
+            },
+            "additionalProperties": true,
+            "type": "object"
+        },
+        "samples.PayloadMessage.Topology": {
+            "enum": [
+                "FLAT",
+                0,
+                "NESTED_OBJECT",
+                1,
+                "NESTED_MESSAGE",
+                2,
+                "ARRAY_OF_TYPE",
+                3,
+                "ARRAY_OF_OBJECT",
+                4,
+                "ARRAY_OF_MESSAGE",
+                5
+            ],
+            "oneOf": [
+                {
+                    "type": "string"
+                },
+                {
+                    "type": "integer"
+                }
+            ]
+        }
+    }
+}`
@@ -0,0 +1,30 @@
+package testdata
+
+const EnumCollisions = `{
+    "$schema": "http://json-schema.org/draft-04/schema#",
+    "properties": {
+        "orderStatus": {
+            "enum": [
+                "PENDING",
+                "SHIPPED"
+            ],
+            "type": "string"
+        },
+        "status": {
+            "enum": [
+                "UNKNOWN",
+                "ACTIVE"
+            ],
+            "type": "string"
+        },
+        "subpackageStatus": {
+            "enum": [
+                "OFFLINE",
+                "ONLINE"
+            ],
+            "type": "string"
+        }
+    },
+    "additionalProperties": true,
+    "type": "object"
+}`
@@ -0,0 +1,35 @@
+package testdata
+
+const EventCollisions = `{
+    "$schema": "http://json-schema.org/draft-04/schema#",
+    "properties": {
+        "auditEvent": {
+            "properties": {
+                "action": {
+                    "type": "string"
+                },
+                "actor": {
+                    "type": "string"
+                }
+            },
+            "additionalProperties": true,
+            "type": "object"
+        },
+        "billingEvent": {
+            "properties": {
+                "amount": {
+                    "maximum": 2147483647,
+                    "minimum": -2147483648,
+                    "type": "integer"
+                },
+                "invoiceId": {
+                    "type": "string"
+                }
+            },
+            "additionalProperties": true,
+            "type": "object"
+        }
+    },
+    "additionalProperties": true,
+    "type": "object"
+}`
@@ -0,0 +1,10 @@
+package testdata
+
+const External = `{
+    "$schema": "http://json-schema.org/draft-04/schema#",
+    "enum": [
+        "FIZZ",
+        "BUZZ"
+    ],
+    "type": "string"
+}`
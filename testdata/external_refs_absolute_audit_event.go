@@ -0,0 +1,23 @@
+package testdata
+
+const ExternalRefsAbsoluteAuditEvent = `{
+    "$schema": "http://json-schema.org/draft-04/schema#",
+    "id": "https://schemas.example.com/schemas/audit.v2.Event.json",
+    "properties": {
+        "action": {
+            "type": "string"
+        },
+        "actor": {
+            "type": "string"
+        }
+    },
+    "additionalProperties": true,
+    "oneOf": [
+        {
+            "type": "null"
+        },
+        {
+            "type": "object"
+        }
+    ]
+}`
@@ -0,0 +1,25 @@
+package testdata
+
+const ExternalRefsAbsoluteBillingEvent = `{
+    "$schema": "http://json-schema.org/draft-04/schema#",
+    "id": "https://schemas.example.com/schemas/billing.v1.Event.json",
+    "properties": {
+        "amount": {
+            "maximum": 2147483647,
+            "minimum": -2147483648,
+            "type": "integer"
+        },
+        "invoiceId": {
+            "type": "string"
+        }
+    },
+    "additionalProperties": true,
+    "oneOf": [
+        {
+            "type": "null"
+        },
+        {
+            "type": "object"
+        }
+    ]
+}`
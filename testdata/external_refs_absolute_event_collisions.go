@@ -0,0 +1,37 @@
+package testdata
+
+const ExternalRefsAbsoluteEventCollisions = `{
+    "$schema": "http://json-schema.org/draft-04/schema#",
+    "id": "https://schemas.example.com/schemas/samples.EventCollisions.json",
+    "properties": {
+        "auditEvent": {
+            "oneOf": [
+                {
+                    "type": "null"
+                },
+                {
+                    "$ref": "https://schemas.example.com/schemas/audit.v2.Event.json"
+                }
+            ]
+        },
+        "billingEvent": {
+            "oneOf": [
+                {
+                    "type": "null"
+                },
+                {
+                    "$ref": "https://schemas.example.com/schemas/billing.v1.Event.json"
+                }
+            ]
+        }
+    },
+    "additionalProperties": true,
+    "oneOf": [
+        {
+            "type": "null"
+        },
+        {
+            "type": "object"
+        }
+    ]
+}`
@@ -0,0 +1,26 @@
+package testdata
+
+const ExternalRefsEnum = `{
+    "$schema": "http://json-schema.org/draft-04/schema#",
+    "properties": {
+        "orderStatus": {
+            "enum": [
+                "PENDING",
+                "SHIPPED"
+            ],
+            "type": "string"
+        },
+        "status": {
+            "enum": [
+                "UNKNOWN",
+                "ACTIVE"
+            ],
+            "type": "string"
+        },
+        "subpackageStatus": {
+            "$ref": "subpackage/Status.jsonschema"
+        }
+    },
+    "additionalProperties": true,
+    "type": "object"
+}`
@@ -0,0 +1,16 @@
+package testdata
+
+const ExternalRefsRelativeAuditEvent = `{
+    "$schema": "https://json-schema.org/draft/2020-12/schema",
+    "$id": "https://schemas.example.com/audit/v2/Event.jsonschema",
+    "properties": {
+        "action": {
+            "type": "string"
+        },
+        "actor": {
+            "type": "string"
+        }
+    },
+    "additionalProperties": true,
+    "type": "object"
+}`
@@ -0,0 +1,18 @@
+package testdata
+
+const ExternalRefsRelativeBillingEvent = `{
+    "$schema": "https://json-schema.org/draft/2020-12/schema",
+    "$id": "https://schemas.example.com/billing/v1/Event.jsonschema",
+    "properties": {
+        "amount": {
+            "maximum": 2147483647,
+            "minimum": -2147483648,
+            "type": "integer"
+        },
+        "invoiceId": {
+            "type": "string"
+        }
+    },
+    "additionalProperties": true,
+    "type": "object"
+}`
@@ -0,0 +1,16 @@
+package testdata
+
+const ExternalRefsRelativeEventCollisions = `{
+    "$schema": "https://json-schema.org/draft/2020-12/schema",
+    "$id": "https://schemas.example.com/samples/EventCollisions.jsonschema",
+    "properties": {
+        "auditEvent": {
+            "$ref": "../audit/v2/Event.jsonschema"
+        },
+        "billingEvent": {
+            "$ref": "../billing/v1/Event.jsonschema"
+        }
+    },
+    "additionalProperties": true,
+    "type": "object"
+}`
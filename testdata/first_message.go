@@ -7,6 +7,8 @@ const FirstMessage = `{
             "type": "boolean"
         },
         "id1": {
+            "maximum": 2147483647,
+            "minimum": -2147483648,
             "type": "integer"
         },
         "name1": {
@@ -0,0 +1,106 @@
+package testdata
+
+const FloatsProtojson = `{
+    "$schema": "http://json-schema.org/draft-04/schema#",
+    "properties": {
+        "distance": {
+            "oneOf": [
+                {
+                    "type": "number",
+                    "format": "double"
+                },
+                {
+                    "pattern": "^(NaN|-?Infinity|-?(0|[1-9][0-9]*)(\\.[0-9]+)?([eE][+-]?[0-9]+)?)$",
+                    "type": "string"
+                }
+            ]
+        },
+        "offset": {
+            "oneOf": [
+                {
+                    "type": "null"
+                },
+                {
+                    "type": "number",
+                    "format": "double"
+                },
+                {
+                    "pattern": "^(NaN|-?Infinity|-?(0|[1-9][0-9]*)(\\.[0-9]+)?([eE][+-]?[0-9]+)?)$",
+                    "type": "string"
+                }
+            ]
+        },
+        "ratio": {
+            "oneOf": [
+                {
+                    "maximum": 3.4028234663852886e+38,
+                    "minimum": -3.4028234663852886e+38,
+                    "type": "number",
+                    "format": "float"
+                },
+                {
+                    "pattern": "^(NaN|-?Infinity|-?(0|[1-9][0-9]*)(\\.[0-9]+)?([eE][+-]?[0-9]+)?)$",
+                    "type": "string"
+                }
+            ]
+        },
+        "samples": {
+            "items": {
+                "oneOf": [
+                    {
+                        "maximum": 3.4028234663852886e+38,
+                        "minimum": -3.4028234663852886e+38,
+                        "type": "number",
+                        "format": "float"
+                    },
+                    {
+                        "pattern": "^(NaN|-?Infinity|-?(0|[1-9][0-9]*)(\\.[0-9]+)?([eE][+-]?[0-9]+)?)$",
+                        "type": "string"
+                    }
+                ]
+            },
+            "type": "array"
+        },
+        "wrappedOffset": {
+            "oneOf": [
+                {
+                    "type": "null"
+                },
+                {
+                    "type": "number",
+                    "format": "double"
+                },
+                {
+                    "pattern": "^(NaN|-?Infinity|-?(0|[1-9][0-9]*)(\\.[0-9]+)?([eE][+-]?[0-9]+)?)$",
+                    "type": "string"
+                }
+            ]
+        },
+        "wrappedRatio": {
+            "oneOf": [
+                {
+                    "type": "null"
+                },
+                {
+                    "maximum": 3.4028234663852886e+38,
+                    "minimum": -3.4028234663852886e+38,
+                    "type": "number",
+                    "format": "float"
+                },
+                {
+                    "pattern": "^(NaN|-?Infinity|-?(0|[1-9][0-9]*)(\\.[0-9]+)?([eE][+-]?[0-9]+)?)$",
+                    "type": "string"
+                }
+            ]
+        }
+    },
+    "additionalProperties": true,
+    "oneOf": [
+        {
+            "type": "null"
+        },
+        {
+            "type": "object"
+        }
+    ]
+}`
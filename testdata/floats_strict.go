@@ -0,0 +1,50 @@
+package testdata
+
+const FloatsStrict = `{
+    "$schema": "http://json-schema.org/draft-04/schema#",
+    "properties": {
+        "distance": {
+            "type": "number"
+        },
+        "offset": {
+            "type": "number"
+        },
+        "ratio": {
+            "maximum": 3.4028234663852886e+38,
+            "minimum": -3.4028234663852886e+38,
+            "type": "number"
+        },
+        "samples": {
+            "items": {
+                "maximum": 3.4028234663852886e+38,
+                "minimum": -3.4028234663852886e+38,
+                "type": "number"
+            },
+            "type": "array"
+        },
+        "wrappedOffset": {
+            "oneOf": [
+                {
+                    "type": "null"
+                },
+                {
+                    "type": "number"
+                }
+            ]
+        },
+        "wrappedRatio": {
+            "oneOf": [
+                {
+                    "type": "null"
+                },
+                {
+                    "maximum": 3.4028234663852886e+38,
+                    "minimum": -3.4028234663852886e+38,
+                    "type": "number"
+                }
+            ]
+        }
+    },
+    "additionalProperties": true,
+    "type": "object"
+}`
@@ -0,0 +1,99 @@
+package testdata
+
+const Integers = `{
+    "$schema": "http://json-schema.org/draft-04/schema#",
+    "properties": {
+        "fixed32Field": {
+            "maximum": 4294967295,
+            "minimum": 0,
+            "type": "integer"
+        },
+        "fixed64Field": {
+            "oneOf": [
+                {
+                    "maximum": 18446744073709551615,
+                    "minimum": 0,
+                    "type": "integer"
+                },
+                {
+                    "pattern": "^[0-9]+$",
+                    "type": "string"
+                }
+            ]
+        },
+        "int32Field": {
+            "maximum": 2147483647,
+            "minimum": -2147483648,
+            "type": "integer"
+        },
+        "int64Field": {
+            "oneOf": [
+                {
+                    "maximum": 9223372036854775807,
+                    "minimum": -9223372036854775808,
+                    "type": "integer"
+                },
+                {
+                    "pattern": "^-?[0-9]+$",
+                    "type": "string"
+                }
+            ]
+        },
+        "sfixed32Field": {
+            "maximum": 2147483647,
+            "minimum": -2147483648,
+            "type": "integer"
+        },
+        "sfixed64Field": {
+            "oneOf": [
+                {
+                    "maximum": 9223372036854775807,
+                    "minimum": -9223372036854775808,
+                    "type": "integer"
+                },
+                {
+                    "pattern": "^-?[0-9]+$",
+                    "type": "string"
+                }
+            ]
+        },
+        "sint32Field": {
+            "maximum": 2147483647,
+            "minimum": -2147483648,
+            "type": "integer"
+        },
+        "sint64Field": {
+            "oneOf": [
+                {
+                    "maximum": 9223372036854775807,
+                    "minimum": -9223372036854775808,
+                    "type": "integer"
+                },
+                {
+                    "pattern": "^-?[0-9]+$",
+                    "type": "string"
+                }
+            ]
+        },
+        "uint32Field": {
+            "maximum": 4294967295,
+            "minimum": 0,
+            "type": "integer"
+        },
+        "uint64Field": {
+            "oneOf": [
+                {
+                    "maximum": 18446744073709551615,
+                    "minimum": 0,
+                    "type": "integer"
+                },
+                {
+                    "pattern": "^[0-9]+$",
+                    "type": "string"
+                }
+            ]
+        }
+    },
+    "additionalProperties": true,
+    "type": "object"
+}`
@@ -0,0 +1,138 @@
+package testdata
+
+const MapsAllowNullValues = `{
+    "$schema": "http://json-schema.org/draft-04/schema#",
+    "properties": {
+        "colours": {
+            "patternProperties": {
+                "^[0-9]+$": {
+                    "enum": [
+                        "RED",
+                        0,
+                        "GREEN",
+                        1
+                    ],
+                    "oneOf": [
+                        {
+                            "type": "string"
+                        },
+                        {
+                            "type": "integer"
+                        }
+                    ]
+                }
+            },
+            "additionalProperties": false,
+            "type": "object"
+        },
+        "counters": {
+            "patternProperties": {
+                "^-?[0-9]+$": {
+                    "oneOf": [
+                        {
+                            "maximum": 9223372036854775807,
+                            "minimum": -9223372036854775808,
+                            "type": "integer"
+                        },
+                        {
+                            "pattern": "^-?[0-9]+$",
+                            "type": "string"
+                        }
+                    ]
+                }
+            },
+            "additionalProperties": false,
+            "type": "object"
+        },
+        "description": {
+            "type": "string"
+        },
+        "labels": {
+            "additionalProperties": {
+                "type": "string"
+            },
+            "type": "object"
+        },
+        "limits": {
+            "additionalProperties": {
+                "maximum": 2147483647,
+                "minimum": -2147483648,
+                "type": "integer"
+            },
+            "type": "object"
+        },
+        "payloads": {
+            "patternProperties": {
+                "^(true|false)$": {
+                    "properties": {
+                        "complete": {
+                            "type": "boolean"
+                        },
+                        "id": {
+                            "maximum": 2147483647,
+                            "minimum": -2147483648,
+                            "type": "integer"
+                        },
+                        "name": {
+                            "type": "string"
+                        },
+                        "rating": {
+                            "type": "number"
+                        },
+                        "timestamp": {
+                            "type": "string"
+                        },
+                        "topology": {
+                            "enum": [
+                                "FLAT",
+                                0,
+                                "NESTED_OBJECT",
+                                1,
+                                "NESTED_MESSAGE",
+                                2,
+                                "ARRAY_OF_TYPE",
+                                3,
+                                "ARRAY_OF_OBJECT",
+                                4,
+                                "ARRAY_OF_MESSAGE",
+                                5
+                            ],
+                            "oneOf": [
+                                {
+                                    "type": "string"
+                                },
+                                {
+                                    "type": "integer"
+                                }
+                            ]
+                        }
+                    },
+                    "additionalProperties": true,
+                    "type": "object"
+                }
+            },
+            "additionalProperties": false,
+            "type": "object"
+        },
+        "settings": {
+            "additionalProperties": {},
+            "type": "object"
+        },
+        "timeouts": {
+            "additionalProperties": {
+                "pattern": "^-?[0-9]+(\\.[0-9]{1,9})?s$",
+                "type": "string"
+            },
+            "type": "object"
+        }
+    },
+    "additionalProperties": true,
+    "oneOf": [
+        {
+            "type": "null"
+        },
+        {
+            "type": "object"
+        }
+    ]
+}`
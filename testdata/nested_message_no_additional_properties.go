@@ -12,6 +12,8 @@ const NestedMessageNoAdditionalProperties = `{
                     "type": "boolean"
                 },
                 "id": {
+                    "maximum": 2147483647,
+                    "minimum": -2147483648,
                     "type": "integer"
                 },
                 "name": {
@@ -0,0 +1,75 @@
+package testdata
+
+const NestedTypesOuter = `{
+    "$schema": "http://json-schema.org/draft-04/schema#",
+    "properties": {
+        "inner": {
+            "properties": {
+                "leaves": {
+                    "additionalProperties": {
+                        "properties": {
+                            "status": {
+                                "enum": [
+                                    "UNKNOWN",
+                                    0,
+                                    "ACTIVE",
+                                    1
+                                ],
+                                "oneOf": [
+                                    {
+                                        "type": "string"
+                                    },
+                                    {
+                                        "type": "integer"
+                                    }
+                                ]
+                            }
+                        },
+                        "additionalProperties": true,
+                        "type": "object"
+                    },
+                    "type": "object"
+                },
+                "name": {
+                    "type": "string"
+                },
+                "priority": {
+                    "enum": [
+                        "LOW",
+                        0,
+                        "HIGH",
+                        1
+                    ],
+                    "oneOf": [
+                        {
+                            "type": "string"
+                        },
+                        {
+                            "type": "integer"
+                        }
+                    ]
+                }
+            },
+            "additionalProperties": true,
+            "type": "object"
+        },
+        "status": {
+            "enum": [
+                "UNKNOWN",
+                0,
+                "ACTIVE",
+                1
+            ],
+            "oneOf": [
+                {
+                    "type": "string"
+                },
+                {
+                    "type": "integer"
+                }
+            ]
+        }
+    },
+    "additionalProperties": true,
+    "type": "object"
+}`
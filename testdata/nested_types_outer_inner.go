@@ -0,0 +1,53 @@
+package testdata
+
+const NestedTypesOuterInner = `{
+    "$schema": "http://json-schema.org/draft-04/schema#",
+    "properties": {
+        "leaves": {
+            "additionalProperties": {
+                "properties": {
+                    "status": {
+                        "enum": [
+                            "UNKNOWN",
+                            0,
+                            "ACTIVE",
+                            1
+                        ],
+                        "oneOf": [
+                            {
+                                "type": "string"
+                            },
+                            {
+                                "type": "integer"
+                            }
+                        ]
+                    }
+                },
+                "additionalProperties": true,
+                "type": "object"
+            },
+            "type": "object"
+        },
+        "name": {
+            "type": "string"
+        },
+        "priority": {
+            "enum": [
+                "LOW",
+                0,
+                "HIGH",
+                1
+            ],
+            "oneOf": [
+                {
+                    "type": "string"
+                },
+                {
+                    "type": "integer"
+                }
+            ]
+        }
+    },
+    "additionalProperties": true,
+    "type": "object"
+}`
@@ -0,0 +1,25 @@
+package testdata
+
+const NestedTypesOuterInnerLeaf = `{
+    "$schema": "http://json-schema.org/draft-04/schema#",
+    "properties": {
+        "status": {
+            "enum": [
+                "UNKNOWN",
+                0,
+                "ACTIVE",
+                1
+            ],
+            "oneOf": [
+                {
+                    "type": "string"
+                },
+                {
+                    "type": "integer"
+                }
+            ]
+        }
+    },
+    "additionalProperties": true,
+    "type": "object"
+}`
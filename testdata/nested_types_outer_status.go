@@ -0,0 +1,19 @@
+package testdata
+
+const NestedTypesOuterStatus = `{
+    "$schema": "http://json-schema.org/draft-04/schema#",
+    "enum": [
+        "UNKNOWN",
+        0,
+        "ACTIVE",
+        1
+    ],
+    "oneOf": [
+        {
+            "type": "string"
+        },
+        {
+            "type": "integer"
+        }
+    ]
+}`
@@ -0,0 +1,19 @@
+package testdata
+
+const NestedTypesPriority = `{
+    "$schema": "http://json-schema.org/draft-04/schema#",
+    "enum": [
+        "LOW",
+        0,
+        "HIGH",
+        1
+    ],
+    "oneOf": [
+        {
+            "type": "string"
+        },
+        {
+            "type": "integer"
+        }
+    ]
+}`
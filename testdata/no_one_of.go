@@ -4,6 +4,8 @@ const NoOneOf = `{
     "$schema": "http://json-schema.org/draft-04/schema#",
     "properties": {
         "bigNumber": {
+            "maximum": 9223372036854775807,
+            "minimum": -9223372036854775808,
             "type": "integer"
         },
         "someChoice": {
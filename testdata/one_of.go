@@ -0,0 +1,144 @@
+package testdata
+
+const OneOf = `{
+    "$schema": "http://json-schema.org/draft-04/schema#",
+    "properties": {
+        "description": {
+            "type": "string"
+        },
+        "json": {
+            "type": "boolean"
+        },
+        "name": {
+            "type": "string"
+        },
+        "number": {
+            "maximum": 2147483647,
+            "minimum": -2147483648,
+            "type": "integer"
+        },
+        "payload": {
+            "properties": {
+                "index": {
+                    "maximum": 2147483647,
+                    "minimum": -2147483648,
+                    "type": "integer"
+                },
+                "uuid": {
+                    "type": "string"
+                }
+            },
+            "additionalProperties": true,
+            "type": "object",
+            "allOf": [
+                {
+                    "oneOf": [
+                        {
+                            "required": [
+                                "uuid"
+                            ]
+                        },
+                        {
+                            "required": [
+                                "index"
+                            ]
+                        },
+                        {
+                            "not": {
+                                "anyOf": [
+                                    {
+                                        "required": [
+                                            "uuid"
+                                        ]
+                                    },
+                                    {
+                                        "required": [
+                                            "index"
+                                        ]
+                                    }
+                                ]
+                            }
+                        }
+                    ]
+                }
+            ]
+        },
+        "yaml": {
+            "type": "boolean"
+        }
+    },
+    "additionalProperties": true,
+    "type": "object",
+    "allOf": [
+        {
+            "oneOf": [
+                {
+                    "required": [
+                        "name"
+                    ]
+                },
+                {
+                    "required": [
+                        "number"
+                    ]
+                },
+                {
+                    "required": [
+                        "payload"
+                    ]
+                },
+                {
+                    "not": {
+                        "anyOf": [
+                            {
+                                "required": [
+                                    "name"
+                                ]
+                            },
+                            {
+                                "required": [
+                                    "number"
+                                ]
+                            },
+                            {
+                                "required": [
+                                    "payload"
+                                ]
+                            }
+                        ]
+                    }
+                }
+            ]
+        },
+        {
+            "oneOf": [
+                {
+                    "required": [
+                        "json"
+                    ]
+                },
+                {
+                    "required": [
+                        "yaml"
+                    ]
+                },
+                {
+                    "not": {
+                        "anyOf": [
+                            {
+                                "required": [
+                                    "json"
+                                ]
+                            },
+                            {
+                                "required": [
+                                    "yaml"
+                                ]
+                            }
+                        ]
+                    }
+                }
+            ]
+        }
+    ]
+}`
@@ -0,0 +1,200 @@
+package testdata
+
+const OneOfAllowNullValues = `{
+    "$schema": "http://json-schema.org/draft-04/schema#",
+    "properties": {
+        "description": {
+            "type": "string"
+        },
+        "json": {
+            "oneOf": [
+                {
+                    "type": "null"
+                },
+                {
+                    "type": "boolean"
+                }
+            ]
+        },
+        "name": {
+            "oneOf": [
+                {
+                    "type": "null"
+                },
+                {
+                    "type": "string"
+                }
+            ]
+        },
+        "number": {
+            "oneOf": [
+                {
+                    "type": "null"
+                },
+                {
+                    "maximum": 2147483647,
+                    "minimum": -2147483648,
+                    "type": "integer"
+                }
+            ]
+        },
+        "payload": {
+            "properties": {
+                "index": {
+                    "oneOf": [
+                        {
+                            "type": "null"
+                        },
+                        {
+                            "maximum": 2147483647,
+                            "minimum": -2147483648,
+                            "type": "integer"
+                        }
+                    ]
+                },
+                "uuid": {
+                    "oneOf": [
+                        {
+                            "type": "null"
+                        },
+                        {
+                            "type": "string"
+                        }
+                    ]
+                }
+            },
+            "additionalProperties": true,
+            "oneOf": [
+                {
+                    "type": "null"
+                },
+                {
+                    "type": "object",
+                    "allOf": [
+                        {
+                            "oneOf": [
+                                {
+                                    "required": [
+                                        "uuid"
+                                    ]
+                                },
+                                {
+                                    "required": [
+                                        "index"
+                                    ]
+                                },
+                                {
+                                    "not": {
+                                        "anyOf": [
+                                            {
+                                                "required": [
+                                                    "uuid"
+                                                ]
+                                            },
+                                            {
+                                                "required": [
+                                                    "index"
+                                                ]
+                                            }
+                                        ]
+                                    }
+                                }
+                            ]
+                        }
+                    ]
+                }
+            ]
+        },
+        "yaml": {
+            "oneOf": [
+                {
+                    "type": "null"
+                },
+                {
+                    "type": "boolean"
+                }
+            ]
+        }
+    },
+    "additionalProperties": true,
+    "oneOf": [
+        {
+            "type": "null"
+        },
+        {
+            "type": "object",
+            "allOf": [
+                {
+                    "oneOf": [
+                        {
+                            "required": [
+                                "name"
+                            ]
+                        },
+                        {
+                            "required": [
+                                "number"
+                            ]
+                        },
+                        {
+                            "required": [
+                                "payload"
+                            ]
+                        },
+                        {
+                            "not": {
+                                "anyOf": [
+                                    {
+                                        "required": [
+                                            "name"
+                                        ]
+                                    },
+                                    {
+                                        "required": [
+                                            "number"
+                                        ]
+                                    },
+                                    {
+                                        "required": [
+                                            "payload"
+                                        ]
+                                    }
+                                ]
+                            }
+                        }
+                    ]
+                },
+                {
+                    "oneOf": [
+                        {
+                            "required": [
+                                "json"
+                            ]
+                        },
+                        {
+                            "required": [
+                                "yaml"
+                            ]
+                        },
+                        {
+                            "not": {
+                                "anyOf": [
+                                    {
+                                        "required": [
+                                            "json"
+                                        ]
+                                    },
+                                    {
+                                        "required": [
+                                            "yaml"
+                                        ]
+                                    }
+                                ]
+                            }
+                        }
+                    ]
+                }
+            ]
+        }
+    ]
+}`
@@ -0,0 +1,105 @@
+package testdata
+
+const Options = `{
+    "$schema": "http://json-schema.org/draft-04/schema#",
+    "properties": {
+        "email": {
+            "type": "string",
+            "title": "Email address",
+            "format": "email"
+        },
+        "extra": {
+            "items": {
+                "$schema": "http://json-schema.org/draft-04/schema#",
+                "properties": {
+                    "name": {
+                        "maxLength": 64,
+                        "minLength": 1,
+                        "pattern": "^[a-z]+$",
+                        "type": "string"
+                    }
+                },
+                "additionalProperties": true,
+                "type": "object",
+                "title": "Allows extra properties (overriding the file option):",
+                "description": "Allows extra properties (overriding the file option):"
+            },
+            "maxItems": 3,
+            "type": "array"
+        },
+        "labels": {
+            "properties": {
+                "name": {
+                    "maxLength": 64,
+                    "minLength": 1,
+                    "pattern": "^[a-z]+$",
+                    "type": "string"
+                }
+            },
+            "additionalProperties": true,
+            "type": "object"
+        },
+        "level": {
+            "pattern": "^[A-Z]+$",
+            "enum": [
+                "ACTIVE",
+                0,
+                "INACTIVE",
+                1
+            ],
+            "oneOf": [
+                {
+                    "type": "string"
+                },
+                {
+                    "type": "integer"
+                }
+            ],
+            "title": "Level"
+        },
+        "notes": {
+            "items": {
+                "maxLength": 0,
+                "type": "string"
+            },
+            "maxItems": 0,
+            "type": "array"
+        },
+        "percentage": {
+            "maximum": 100,
+            "minimum": 1,
+            "type": "integer",
+            "default": 50
+        },
+        "status": {
+            "enum": [
+                "ACTIVE",
+                0,
+                "INACTIVE",
+                1
+            ],
+            "oneOf": [
+                {
+                    "type": "string"
+                },
+                {
+                    "type": "integer"
+                }
+            ],
+            "default": "ACTIVE"
+        },
+        "tags": {
+            "items": {
+                "minLength": 2,
+                "type": "string"
+            },
+            "minItems": 1,
+            "uniqueItems": true,
+            "type": "array"
+        }
+    },
+    "additionalProperties": false,
+    "type": "object",
+    "title": "Options",
+    "description": "A message with JSON-Schema options"
+}`
@@ -0,0 +1,97 @@
+package testdata
+
+const OptionsWithDefinitions = `{
+    "$schema": "http://json-schema.org/draft-04/schema#",
+    "properties": {
+        "email": {
+            "type": "string",
+            "title": "Email address",
+            "format": "email"
+        },
+        "extra": {
+            "items": {
+                "$ref": "#/definitions/samples.Options.Labels"
+            },
+            "maxItems": 3,
+            "type": "array"
+        },
+        "labels": {
+            "$ref": "#/definitions/samples.Options.Labels"
+        },
+        "level": {
+            "allOf": [
+                {
+                    "$ref": "#/definitions/samples.Options.Status"
+                },
+                {
+                    "pattern": "^[A-Z]+$"
+                }
+            ],
+            "title": "Level"
+        },
+        "notes": {
+            "items": {
+                "maxLength": 0,
+                "type": "string"
+            },
+            "maxItems": 0,
+            "type": "array"
+        },
+        "percentage": {
+            "maximum": 100,
+            "minimum": 1,
+            "type": "integer",
+            "default": 50
+        },
+        "status": {
+            "$ref": "#/definitions/samples.Options.Status",
+            "default": "ACTIVE"
+        },
+        "tags": {
+            "items": {
+                "minLength": 2,
+                "type": "string"
+            },
+            "minItems": 1,
+            "uniqueItems": true,
+            "type": "array"
+        }
+    },
+    "additionalProperties": false,
+    "type": "object",
+    "definitions": {
+        "samples.Options.Labels": {
+            "properties": {
+                "name": {
+                    "maxLength": 64,
+                    "minLength": 1,
+                    "pattern": "^[a-z]+$",
+                    "type": "string"
+                }
+            },
+            "additionalProperties": true,
+            "type": "object",
+            "title": "Allows extra properties (overriding the file option):",
+            "description": "Allows extra properties (overriding the file option):"
+        },
+        "samples.Options.Status": {
+            "enum": [
+                "ACTIVE",
+                0,
+                "INACTIVE",
+                1
+            ],
+            "oneOf": [
+                {
+                    "type": "string"
+                },
+                {
+                    "type": "integer"
+                }
+            ],
+            "title": "Status"
+        }
+    },
+    "title": "Options",
+    "description": "A message with JSON-Schema options"
+}`
@@ -0,0 +1,10 @@
+package testdata
+
+const OrderStatus = `{
+    "$schema": "http://json-schema.org/draft-04/schema#",
+    "enum": [
+        "PENDING",
+        "SHIPPED"
+    ],
+    "type": "string"
+}`
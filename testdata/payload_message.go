@@ -7,6 +7,8 @@ const PayloadMessage = `{
             "type": "boolean"
         },
         "id": {
+            "maximum": 2147483647,
+            "minimum": -2147483648,
             "type": "integer"
         },
         "name": {
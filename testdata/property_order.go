@@ -0,0 +1,69 @@
+package testdata
+
+const PropertyOrder = `{
+    "$schema": "http://json-schema.org/draft-04/schema#",
+    "properties": {
+        "apple": {
+            "type": "string"
+        },
+        "banana": {
+            "type": "string"
+        },
+        "mango": {
+            "properties": {
+                "first": {
+                    "maximum": 2147483647,
+                    "minimum": -2147483648,
+                    "type": "integer"
+                },
+                "second": {
+                    "maximum": 2147483647,
+                    "minimum": -2147483648,
+                    "type": "integer"
+                }
+            },
+            "additionalProperties": true,
+            "type": "object"
+        },
+        "pear": {
+            "type": "string"
+        },
+        "zebra": {
+            "type": "string"
+        }
+    },
+    "additionalProperties": true,
+    "type": "object",
+    "allOf": [
+        {
+            "oneOf": [
+                {
+                    "required": [
+                        "pear"
+                    ]
+                },
+                {
+                    "required": [
+                        "banana"
+                    ]
+                },
+                {
+                    "not": {
+                        "anyOf": [
+                            {
+                                "required": [
+                                    "pear"
+                                ]
+                            },
+                            {
+                                "required": [
+                                    "banana"
+                                ]
+                            }
+                        ]
+                    }
+                }
+            ]
+        }
+    ]
+}`
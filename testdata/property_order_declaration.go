@@ -0,0 +1,69 @@
+package testdata
+
+const PropertyOrderDeclaration = `{
+    "$schema": "http://json-schema.org/draft-04/schema#",
+    "properties": {
+        "zebra": {
+            "type": "string"
+        },
+        "apple": {
+            "type": "string"
+        },
+        "mango": {
+            "properties": {
+                "second": {
+                    "maximum": 2147483647,
+                    "minimum": -2147483648,
+                    "type": "integer"
+                },
+                "first": {
+                    "maximum": 2147483647,
+                    "minimum": -2147483648,
+                    "type": "integer"
+                }
+            },
+            "additionalProperties": true,
+            "type": "object"
+        },
+        "pear": {
+            "type": "string"
+        },
+        "banana": {
+            "type": "string"
+        }
+    },
+    "additionalProperties": true,
+    "type": "object",
+    "allOf": [
+        {
+            "oneOf": [
+                {
+                    "required": [
+                        "pear"
+                    ]
+                },
+                {
+                    "required": [
+                        "banana"
+                    ]
+                },
+                {
+                    "not": {
+                        "anyOf": [
+                            {
+                                "required": [
+                                    "pear"
+                                ]
+                            },
+                            {
+                                "required": [
+                                    "banana"
+                                ]
+                            }
+                        ]
+                    }
+                }
+            ]
+        }
+    ]
+}`
@@ -0,0 +1,69 @@
+package testdata
+
+const PropertyOrderFieldNumber = `{
+    "$schema": "http://json-schema.org/draft-04/schema#",
+    "properties": {
+        "apple": {
+            "type": "string"
+        },
+        "mango": {
+            "properties": {
+                "first": {
+                    "maximum": 2147483647,
+                    "minimum": -2147483648,
+                    "type": "integer"
+                },
+                "second": {
+                    "maximum": 2147483647,
+                    "minimum": -2147483648,
+                    "type": "integer"
+                }
+            },
+            "additionalProperties": true,
+            "type": "object"
+        },
+        "zebra": {
+            "type": "string"
+        },
+        "banana": {
+            "type": "string"
+        },
+        "pear": {
+            "type": "string"
+        }
+    },
+    "additionalProperties": true,
+    "type": "object",
+    "allOf": [
+        {
+            "oneOf": [
+                {
+                    "required": [
+                        "banana"
+                    ]
+                },
+                {
+                    "required": [
+                        "pear"
+                    ]
+                },
+                {
+                    "not": {
+                        "anyOf": [
+                            {
+                                "required": [
+                                    "banana"
+                                ]
+                            },
+                            {
+                                "required": [
+                                    "pear"
+                                ]
+                            }
+                        ]
+                    }
+                }
+            ]
+        }
+    ]
+}`
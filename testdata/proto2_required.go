@@ -0,0 +1,40 @@
+package testdata
+
+const Proto2Required = `{
+    "$schema": "http://json-schema.org/draft-04/schema#",
+    "required": [
+        "name",
+        "address"
+    ],
+    "properties": {
+        "address": {
+            "required": [
+                "street"
+            ],
+            "properties": {
+                "city": {
+                    "type": "string"
+                },
+                "street": {
+                    "type": "string"
+                }
+            },
+            "additionalProperties": true,
+            "type": "object"
+        },
+        "name": {
+            "type": "string"
+        },
+        "nickname": {
+            "type": "string"
+        },
+        "tags": {
+            "items": {
+                "type": "string"
+            },
+            "type": "array"
+        }
+    },
+    "additionalProperties": true,
+    "type": "object"
+}`
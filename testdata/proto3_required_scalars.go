@@ -0,0 +1,87 @@
+package testdata
+
+const Proto3RequiredScalars = `{
+    "$schema": "http://json-schema.org/draft-04/schema#",
+    "required": [
+        "name"
+    ],
+    "properties": {
+        "address": {
+            "required": [
+                "street"
+            ],
+            "properties": {
+                "street": {
+                    "type": "string"
+                }
+            },
+            "additionalProperties": true,
+            "type": "object"
+        },
+        "age": {
+            "oneOf": [
+                {
+                    "maximum": 9223372036854775807,
+                    "minimum": -9223372036854775808,
+                    "type": "integer"
+                },
+                {
+                    "pattern": "^-?[0-9]+$",
+                    "type": "string"
+                }
+            ]
+        },
+        "email": {
+            "type": "string"
+        },
+        "name": {
+            "type": "string"
+        },
+        "nickname": {
+            "type": "string"
+        },
+        "phone": {
+            "type": "string"
+        },
+        "tags": {
+            "items": {
+                "type": "string"
+            },
+            "type": "array"
+        }
+    },
+    "additionalProperties": true,
+    "type": "object",
+    "allOf": [
+        {
+            "oneOf": [
+                {
+                    "required": [
+                        "email"
+                    ]
+                },
+                {
+                    "required": [
+                        "phone"
+                    ]
+                },
+                {
+                    "not": {
+                        "anyOf": [
+                            {
+                                "required": [
+                                    "email"
+                                ]
+                            },
+                            {
+                                "required": [
+                                    "phone"
+                                ]
+                            }
+                        ]
+                    }
+                }
+            ]
+        }
+    ]
+}`
@@ -0,0 +1,61 @@
+package testdata
+
+const ProtoNames = `{
+    "$schema": "http://json-schema.org/draft-04/schema#",
+    "required": [
+        "first_name"
+    ],
+    "properties": {
+        "age": {
+            "maximum": 2147483647,
+            "minimum": -2147483648,
+            "type": "integer"
+        },
+        "email_address": {
+            "type": "string"
+        },
+        "first_name": {
+            "type": "string"
+        },
+        "last_name": {
+            "type": "string"
+        },
+        "phone_number": {
+            "type": "string"
+        }
+    },
+    "additionalProperties": true,
+    "type": "object",
+    "allOf": [
+        {
+            "oneOf": [
+                {
+                    "required": [
+                        "email_address"
+                    ]
+                },
+                {
+                    "required": [
+                        "phone_number"
+                    ]
+                },
+                {
+                    "not": {
+                        "anyOf": [
+                            {
+                                "required": [
+                                    "email_address"
+                                ]
+                            },
+                            {
+                                "required": [
+                                    "phone_number"
+                                ]
+                            }
+                        ]
+                    }
+                }
+            ]
+        }
+    ]
+}`
@@ -0,0 +1,152 @@
+package testdata
+
+const ProtoNamesBoth = `{
+    "$schema": "http://json-schema.org/draft-04/schema#",
+    "properties": {
+        "age": {
+            "maximum": 2147483647,
+            "minimum": -2147483648,
+            "type": "integer"
+        },
+        "emailAddress": {
+            "type": "string"
+        },
+        "email_address": {
+            "type": "string"
+        },
+        "firstName": {
+            "type": "string"
+        },
+        "first_name": {
+            "type": "string"
+        },
+        "lastName": {
+            "type": "string"
+        },
+        "last_name": {
+            "type": "string"
+        },
+        "phoneNumber": {
+            "type": "string"
+        },
+        "phone_number": {
+            "type": "string"
+        }
+    },
+    "additionalProperties": true,
+    "type": "object",
+    "allOf": [
+        {
+            "not": {
+                "required": [
+                    "firstName",
+                    "first_name"
+                ]
+            }
+        },
+        {
+            "anyOf": [
+                {
+                    "required": [
+                        "firstName"
+                    ]
+                },
+                {
+                    "required": [
+                        "first_name"
+                    ]
+                }
+            ]
+        },
+        {
+            "not": {
+                "required": [
+                    "lastName",
+                    "last_name"
+                ]
+            }
+        },
+        {
+            "not": {
+                "required": [
+                    "emailAddress",
+                    "email_address"
+                ]
+            }
+        },
+        {
+            "not": {
+                "required": [
+                    "phoneNumber",
+                    "phone_number"
+                ]
+            }
+        },
+        {
+            "oneOf": [
+                {
+                    "anyOf": [
+                        {
+                            "required": [
+                                "emailAddress"
+                            ]
+                        },
+                        {
+                            "required": [
+                                "email_address"
+                            ]
+                        }
+                    ]
+                },
+                {
+                    "anyOf": [
+                        {
+                            "required": [
+                                "phoneNumber"
+                            ]
+                        },
+                        {
+                            "required": [
+                                "phone_number"
+                            ]
+                        }
+                    ]
+                },
+                {
+                    "not": {
+                        "anyOf": [
+                            {
+                                "anyOf": [
+                                    {
+                                        "required": [
+                                            "emailAddress"
+                                        ]
+                                    },
+                                    {
+                                        "required": [
+                                            "email_address"
+                                        ]
+                                    }
+                                ]
+                            },
+                            {
+                                "anyOf": [
+                                    {
+                                        "required": [
+                                            "phoneNumber"
+                                        ]
+                                    },
+                                    {
+                                        "required": [
+                                            "phone_number"
+                                        ]
+                                    }
+                                ]
+                            }
+                        ]
+                    }
+                }
+            ]
+        }
+    ]
+}`
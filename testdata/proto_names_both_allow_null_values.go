@@ -0,0 +1,222 @@
+package testdata
+
+const ProtoNamesBothAllowNullValues = `{
+    "$schema": "http://json-schema.org/draft-04/schema#",
+    "properties": {
+        "age": {
+            "oneOf": [
+                {
+                    "type": "null"
+                },
+                {
+                    "maximum": 2147483647,
+                    "minimum": -2147483648,
+                    "type": "integer"
+                }
+            ]
+        },
+        "emailAddress": {
+            "oneOf": [
+                {
+                    "type": "null"
+                },
+                {
+                    "type": "string"
+                }
+            ]
+        },
+        "email_address": {
+            "oneOf": [
+                {
+                    "type": "null"
+                },
+                {
+                    "type": "string"
+                }
+            ]
+        },
+        "firstName": {
+            "oneOf": [
+                {
+                    "type": "null"
+                },
+                {
+                    "type": "string"
+                }
+            ]
+        },
+        "first_name": {
+            "oneOf": [
+                {
+                    "type": "null"
+                },
+                {
+                    "type": "string"
+                }
+            ]
+        },
+        "lastName": {
+            "oneOf": [
+                {
+                    "type": "null"
+                },
+                {
+                    "type": "string"
+                }
+            ]
+        },
+        "last_name": {
+            "oneOf": [
+                {
+                    "type": "null"
+                },
+                {
+                    "type": "string"
+                }
+            ]
+        },
+        "phoneNumber": {
+            "oneOf": [
+                {
+                    "type": "null"
+                },
+                {
+                    "type": "string"
+                }
+            ]
+        },
+        "phone_number": {
+            "oneOf": [
+                {
+                    "type": "null"
+                },
+                {
+                    "type": "string"
+                }
+            ]
+        }
+    },
+    "additionalProperties": true,
+    "oneOf": [
+        {
+            "type": "null"
+        },
+        {
+            "type": "object",
+            "allOf": [
+                {
+                    "not": {
+                        "required": [
+                            "firstName",
+                            "first_name"
+                        ]
+                    }
+                },
+                {
+                    "anyOf": [
+                        {
+                            "required": [
+                                "firstName"
+                            ]
+                        },
+                        {
+                            "required": [
+                                "first_name"
+                            ]
+                        }
+                    ]
+                },
+                {
+                    "not": {
+                        "required": [
+                            "lastName",
+                            "last_name"
+                        ]
+                    }
+                },
+                {
+                    "not": {
+                        "required": [
+                            "emailAddress",
+                            "email_address"
+                        ]
+                    }
+                },
+                {
+                    "not": {
+                        "required": [
+                            "phoneNumber",
+                            "phone_number"
+                        ]
+                    }
+                },
+                {
+                    "oneOf": [
+                        {
+                            "anyOf": [
+                                {
+                                    "required": [
+                                        "emailAddress"
+                                    ]
+                                },
+                                {
+                                    "required": [
+                                        "email_address"
+                                    ]
+                                }
+                            ]
+                        },
+                        {
+                            "anyOf": [
+                                {
+                                    "required": [
+                                        "phoneNumber"
+                                    ]
+                                },
+                                {
+                                    "required": [
+                                        "phone_number"
+                                    ]
+                                }
+                            ]
+                        },
+                        {
+                            "not": {
+                                "anyOf": [
+                                    {
+                                        "anyOf": [
+                                            {
+                                                "required": [
+                                                    "emailAddress"
+                                                ]
+                                            },
+                                            {
+                                                "required": [
+                                                    "email_address"
+                                                ]
+                                            }
+                                        ]
+                                    },
+                                    {
+                                        "anyOf": [
+                                            {
+                                                "required": [
+                                                    "phoneNumber"
+                                                ]
+                                            },
+                                            {
+                                                "required": [
+                                                    "phone_number"
+                                                ]
+                                            }
+                                        ]
+                                    }
+                                ]
+                            }
+                        }
+                    ]
+                }
+            ]
+        }
+    ]
+}`
@@ -0,0 +1,71 @@
+package testdata
+
+const Recursion = `{
+    "$schema": "http://json-schema.org/draft-04/schema#",
+    "properties": {
+        "graph": {
+            "$ref": "#/definitions/samples.Recursion.Graph"
+        },
+        "parent": {
+            "$ref": "#"
+        },
+        "ping": {
+            "$ref": "#/definitions/samples.Recursion.Ping"
+        },
+        "tree": {
+            "$ref": "#/definitions/samples.Recursion.Node"
+        }
+    },
+    "additionalProperties": true,
+    "type": "object",
+    "definitions": {
+        "samples.Recursion.Graph": {
+            "properties": {
+                "neighbours": {
+                    "additionalProperties": {
+                        "$ref": "#/definitions/samples.Recursion.Graph"
+                    },
+                    "type": "object"
+                }
+            },
+            "additionalProperties": true,
+            "type": "object",
+            "title": "Recursion through a map:",
+            "description": "Recursion through a map:"
+        },
+        "samples.Recursion.Node": {
+            "properties": {
+                "children": {
+                    "items": {
+                        "$ref": "#/definitions/samples.Recursion.Node"
+                    },
+                    "type": "array"
+                },
+                "value": {
+                    "type": "string"
+                }
+            },
+            "additionalProperties": true,
+            "type": "object",
+            "title": "Direct recursion:",
+            "description": "Direct recursion:"
+        },
+        "samples.Recursion.Ping": {
+            "properties": {
+                "pong": {
+                    "properties": {
+                        "ping": {
+                            "$ref": "#/definitions/samples.Recursion.Ping"
+                        }
+                    },
+                    "additionalProperties": true,
+                    "type": "object"
+                }
+            },
+            "additionalProperties": true,
+            "type": "object",
+            "title": "Indirect recursion:",
+            "description": "Indirect recursion:"
+        }
+    }
+}`
@@ -0,0 +1,71 @@
+package testdata
+
+const RecursionDraft201909 = `{
+    "$schema": "https://json-schema.org/draft/2019-09/schema",
+    "properties": {
+        "graph": {
+            "$ref": "#/$defs/samples.Recursion.Graph"
+        },
+        "parent": {
+            "$ref": "#"
+        },
+        "ping": {
+            "$ref": "#/$defs/samples.Recursion.Ping"
+        },
+        "tree": {
+            "$ref": "#/$defs/samples.Recursion.Node"
+        }
+    },
+    "additionalProperties": true,
+    "type": "object",
+    "$defs": {
+        "samples.Recursion.Graph": {
+            "properties": {
+                "neighbours": {
+                    "additionalProperties": {
+                        "$ref": "#/$defs/samples.Recursion.Graph"
+                    },
+                    "type": "object"
+                }
+            },
+            "additionalProperties": true,
+            "type": "object",
+            "title": "Recursion through a map:",
+            "description": "Recursion through a map:"
+        },
+        "samples.Recursion.Node": {
+            "properties": {
+                "children": {
+                    "items": {
+                        "$ref": "#/$defs/samples.Recursion.Node"
+                    },
+                    "type": "array"
+                },
+                "value": {
+                    "type": "string"
+                }
+            },
+            "additionalProperties": true,
+            "type": "object",
+            "title": "Direct recursion:",
+            "description": "Direct recursion:"
+        },
+        "samples.Recursion.Ping": {
+            "properties": {
+                "pong": {
+                    "properties": {
+                        "ping": {
+                            "$ref": "#/$defs/samples.Recursion.Ping"
+                        }
+                    },
+                    "additionalProperties": true,
+                    "type": "object"
+                }
+            },
+            "additionalProperties": true,
+            "type": "object",
+            "title": "Indirect recursion:",
+            "description": "Indirect recursion:"
+        }
+    }
+}`
@@ -7,6 +7,8 @@ const SecondMessage = `{
             "type": "boolean"
         },
         "id2": {
+            "maximum": 2147483647,
+            "minimum": -2147483648,
             "type": "integer"
         },
         "name2": {
@@ -0,0 +1,19 @@
+package testdata
+
+const Shape = `{
+    "$schema": "http://json-schema.org/draft-04/schema#",
+    "enum": [
+        "CIRCLE",
+        0,
+        "SQUARE",
+        1
+    ],
+    "oneOf": [
+        {
+            "type": "string"
+        },
+        {
+            "type": "integer"
+        }
+    ]
+}`
@@ -0,0 +1,10 @@
+package testdata
+
+const SubpackageStatus = `{
+    "$schema": "http://json-schema.org/draft-04/schema#",
+    "enum": [
+        "OFFLINE",
+        "ONLINE"
+    ],
+    "type": "string"
+}`
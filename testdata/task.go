@@ -0,0 +1,28 @@
+package testdata
+
+const Task = `{
+    "$schema": "http://json-schema.org/draft-04/schema#",
+    "properties": {
+        "state": {
+            "enum": [
+                "TODO",
+                0,
+                "DONE",
+                1
+            ],
+            "oneOf": [
+                {
+                    "type": "string"
+                },
+                {
+                    "type": "integer"
+                }
+            ]
+        },
+        "title": {
+            "type": "string"
+        }
+    },
+    "additionalProperties": true,
+    "type": "object"
+}`
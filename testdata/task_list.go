@@ -0,0 +1,38 @@
+package testdata
+
+const TaskList = `{
+    "$schema": "http://json-schema.org/draft-04/schema#",
+    "properties": {
+        "tasks": {
+            "items": {
+                "$schema": "http://json-schema.org/draft-04/schema#",
+                "properties": {
+                    "state": {
+                        "enum": [
+                            "TODO",
+                            0,
+                            "DONE",
+                            1
+                        ],
+                        "oneOf": [
+                            {
+                                "type": "string"
+                            },
+                            {
+                                "type": "integer"
+                            }
+                        ]
+                    },
+                    "title": {
+                        "type": "string"
+                    }
+                },
+                "additionalProperties": true,
+                "type": "object"
+            },
+            "type": "array"
+        }
+    },
+    "additionalProperties": true,
+    "type": "object"
+}`
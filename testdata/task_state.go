@@ -0,0 +1,21 @@
+package testdata
+
+const TaskState = `{
+    "$schema": "http://json-schema.org/draft-04/schema#",
+    "enum": [
+        "TODO",
+        0,
+        "DONE",
+        1
+    ],
+    "oneOf": [
+        {
+            "type": "string"
+        },
+        {
+            "type": "integer"
+        }
+    ],
+    "title": "Top-level enums and messages are declared in between each other, and one of the enums isn't used by any message:",
+    "description": "Top-level enums and messages are declared in between each other, and one of the enums isn't used by any message:"
+}`
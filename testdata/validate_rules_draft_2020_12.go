@@ -0,0 +1,282 @@
+package testdata
+
+const ValidateRulesDraft202012 = `{
+    "$schema": "https://json-schema.org/draft/2020-12/schema",
+    "required": [
+        "address"
+    ],
+    "properties": {
+        "accepted": {
+            "const": true,
+            "type": "boolean"
+        },
+        "address": {
+            "properties": {
+                "line1": {
+                    "type": "string"
+                }
+            },
+            "additionalProperties": true,
+            "type": "object"
+        },
+        "age": {
+            "maximum": 150,
+            "exclusiveMinimum": 17,
+            "type": "integer"
+        },
+        "balance": {
+            "minimum": -100,
+            "allOf": [
+                {
+                    "not": {
+                        "enum": [
+                            0,
+                            "0"
+                        ]
+                    }
+                }
+            ],
+            "oneOf": [
+                {
+                    "maximum": 9223372036854775807,
+                    "minimum": -9223372036854775808,
+                    "type": "integer"
+                },
+                {
+                    "pattern": "^-?[0-9]+$",
+                    "type": "string"
+                }
+            ]
+        },
+        "code": {
+            "pattern": "^ab\\.",
+            "type": "string",
+            "allOf": [
+                {
+                    "not": {
+                        "enum": [
+                            "ab.c",
+                            "ab.d"
+                        ]
+                    }
+                }
+            ]
+        },
+        "comment": {
+            "maxLength": 0,
+            "type": "string"
+        },
+        "count": {
+            "anyOf": [
+                {
+                    "exclusiveMinimum": 10
+                },
+                {
+                    "exclusiveMaximum": 5
+                }
+            ],
+            "oneOf": [
+                {
+                    "maximum": 18446744073709551615,
+                    "minimum": 0,
+                    "type": "integer"
+                },
+                {
+                    "pattern": "^[0-9]+$",
+                    "type": "string"
+                }
+            ]
+        },
+        "data": {
+            "type": "string"
+        },
+        "email": {
+            "type": "string",
+            "format": "email"
+        },
+        "host": {
+            "type": "string",
+            "anyOf": [
+                {
+                    "format": "hostname"
+                },
+                {
+                    "format": "ipv4"
+                },
+                {
+                    "format": "ipv6"
+                }
+            ]
+        },
+        "id": {
+            "pattern": "^[0-9a-fA-F]{8}-[0-9a-fA-F]{4}-[0-9a-fA-F]{4}-[0-9a-fA-F]{4}-[0-9a-fA-F]{12}$",
+            "type": "string"
+        },
+        "initialStatus": {
+            "enum": [
+                "ACTIVE",
+                0
+            ],
+            "oneOf": [
+                {
+                    "type": "string"
+                },
+                {
+                    "type": "integer"
+                }
+            ]
+        },
+        "kind": {
+            "const": "user",
+            "type": "string"
+        },
+        "kv": {
+            "maxProperties": 5,
+            "additionalProperties": {
+                "maximum": 2147483647,
+                "minimum": -2147483648,
+                "type": "integer"
+            },
+            "type": "object"
+        },
+        "lastStatus": {
+            "enum": [
+                "ACTIVE",
+                0,
+                "INACTIVE",
+                1,
+                "DELETED",
+                2
+            ],
+            "allOf": [
+                {
+                    "not": {
+                        "enum": [
+                            "DELETED",
+                            2
+                        ]
+                    }
+                }
+            ],
+            "oneOf": [
+                {
+                    "type": "string"
+                },
+                {
+                    "type": "integer"
+                }
+            ]
+        },
+        "name": {
+            "maxLength": 64,
+            "minLength": 1,
+            "pattern": "^[A-Za-z ]+$",
+            "type": "string"
+        },
+        "nickname": {
+            "pattern": "^[a-z]+$",
+            "type": "string",
+            "allOf": [
+                {
+                    "pattern": "^.{2,8}$"
+                }
+            ]
+        },
+        "offset": {
+            "exclusiveMaximum": 10,
+            "minimum": -10,
+            "type": "integer"
+        },
+        "ratio": {
+            "exclusiveMaximum": 1,
+            "minimum": 0.5,
+            "type": "number"
+        },
+        "revision": {
+            "enum": [
+                5,
+                "5"
+            ],
+            "oneOf": [
+                {
+                    "maximum": 9223372036854775807,
+                    "minimum": -9223372036854775808,
+                    "type": "integer"
+                },
+                {
+                    "pattern": "^-?[0-9]+$",
+                    "type": "string"
+                }
+            ]
+        },
+        "slot": {
+            "maximum": 2147483647,
+            "minimum": -2147483648,
+            "enum": [
+                1,
+                2,
+                3
+            ],
+            "type": "integer"
+        },
+        "spare": {
+            "items": {
+                "maximum": 2147483647,
+                "minimum": -2147483648,
+                "type": "integer"
+            },
+            "maxItems": 0,
+            "type": "array"
+        },
+        "state": {
+            "enum": [
+                "ACTIVE",
+                0,
+                "INACTIVE",
+                1
+            ],
+            "oneOf": [
+                {
+                    "type": "string"
+                },
+                {
+                    "type": "integer"
+                }
+            ]
+        },
+        "status": {
+            "enum": [
+                "ACTIVE",
+                0,
+                "INACTIVE",
+                1,
+                "DELETED",
+                2
+            ],
+            "oneOf": [
+                {
+                    "type": "string"
+                },
+                {
+                    "type": "integer"
+                }
+            ]
+        },
+        "tags": {
+            "items": {
+                "minLength": 2,
+                "type": "string"
+            },
+            "minItems": 1,
+            "uniqueItems": true,
+            "type": "array"
+        },
+        "unused": {
+            "maxLength": 0,
+            "minLength": 0,
+            "type": "string"
+        }
+    },
+    "additionalProperties": true,
+    "type": "object"
+}`
@@ -0,0 +1,161 @@
+package testdata
+
+const WellKnown = `{
+    "$schema": "http://json-schema.org/draft-04/schema#",
+    "properties": {
+        "any": {
+            "required": [
+                "@type"
+            ],
+            "properties": {
+                "@type": {
+                    "type": "string"
+                }
+            },
+            "additionalProperties": true,
+            "type": "object"
+        },
+        "boolValue": {
+            "oneOf": [
+                {
+                    "type": "null"
+                },
+                {
+                    "type": "boolean"
+                }
+            ]
+        },
+        "bytesValue": {
+            "oneOf": [
+                {
+                    "type": "null"
+                },
+                {
+                    "type": "string"
+                }
+            ]
+        },
+        "doubleValue": {
+            "oneOf": [
+                {
+                    "type": "null"
+                },
+                {
+                    "type": "number"
+                }
+            ]
+        },
+        "duration": {
+            "pattern": "^-?[0-9]+(\\.[0-9]{1,9})?s$",
+            "type": "string"
+        },
+        "durations": {
+            "items": {
+                "pattern": "^-?[0-9]+(\\.[0-9]{1,9})?s$",
+                "type": "string"
+            },
+            "type": "array"
+        },
+        "empty": {
+            "additionalProperties": true,
+            "type": "object"
+        },
+        "fieldMask": {
+            "pattern": "^([a-z][a-zA-Z0-9]*(\\.[a-z][a-zA-Z0-9]*)*(,[a-z][a-zA-Z0-9]*(\\.[a-z][a-zA-Z0-9]*)*)*)?$",
+            "type": "string"
+        },
+        "floatValue": {
+            "oneOf": [
+                {
+                    "type": "null"
+                },
+                {
+                    "type": "number"
+                }
+            ]
+        },
+        "int32Value": {
+            "oneOf": [
+                {
+                    "type": "null"
+                },
+                {
+                    "maximum": 2147483647,
+                    "minimum": -2147483648,
+                    "type": "integer"
+                }
+            ]
+        },
+        "int64Value": {
+            "oneOf": [
+                {
+                    "type": "null"
+                },
+                {
+                    "maximum": 9223372036854775807,
+                    "minimum": -9223372036854775808,
+                    "type": "integer"
+                },
+                {
+                    "pattern": "^-?[0-9]+$",
+                    "type": "string"
+                }
+            ]
+        },
+        "listValue": {
+            "type": "array"
+        },
+        "nullValue": {
+            "type": "null"
+        },
+        "stringValue": {
+            "oneOf": [
+                {
+                    "type": "null"
+                },
+                {
+                    "type": "string"
+                }
+            ]
+        },
+        "struct": {
+            "additionalProperties": true,
+            "type": "object"
+        },
+        "timestamp": {
+            "type": "string",
+            "format": "date-time"
+        },
+        "uint32Value": {
+            "oneOf": [
+                {
+                    "type": "null"
+                },
+                {
+                    "maximum": 4294967295,
+                    "minimum": 0,
+                    "type": "integer"
+                }
+            ]
+        },
+        "uint64Value": {
+            "oneOf": [
+                {
+                    "type": "null"
+                },
+                {
+                    "maximum": 18446744073709551615,
+                    "minimum": 0,
+                    "type": "integer"
+                },
+                {
+                    "pattern": "^[0-9]+$",
+                    "type": "string"
+                }
+            ]
+        },
+        "value": {}
+    },
+    "additionalProperties": true,
+    "type": "object"
+}`
@@ -0,0 +1,255 @@
+package main
+
+import (
+	"github.com/RedVentures/protoc-gen-jsonschema/internal/jsonschema"
+	"github.com/golang/protobuf/protoc-gen-go/descriptor"
+	"github.com/xeipuuv/gojsonschema"
+)
+
+// wellKnownType describes how one of Google's "well-known types" (google/protobuf/*.proto) is represented in JSON.
+// See https://developers.google.com/protocol-buffers/docs/proto3#json for the canonical mapping.
+type wellKnownType struct {
+	nullable bool                    // NULL is already a valid value (so it doesn't need to be allowed again)
+	convert  func() *jsonschema.Type // Returns the JSON-Schema for a single value
+}
+
+// The well-known types, keyed by their fully-qualified proto names:
+var wellKnownTypes = map[string]wellKnownType{
+	".google.protobuf.Any": {
+		convert: func() *jsonschema.Type {
+			return &jsonschema.Type{
+				Type:     gojsonschema.TYPE_OBJECT,
+				Required: []string{"@type"},
+				Properties: map[string]*jsonschema.Type{
+					"@type": {Type: gojsonschema.TYPE_STRING},
+				},
+				AdditionalProperties: jsonschema.Bool(true),
+			}
+		},
+	},
+	".google.protobuf.Duration": {
+		convert: func() *jsonschema.Type {
+			return &jsonschema.Type{
+				Type:    gojsonschema.TYPE_STRING,
+				Pattern: `^-?[0-9]+(\.[0-9]{1,9})?s$`,
+			}
+		},
+	},
+	".google.protobuf.Empty": {
+		convert: func() *jsonschema.Type {
+			jsonSchemaType := &jsonschema.Type{Type: gojsonschema.TYPE_OBJECT}
+			if disallowAdditionalProperties {
+				jsonSchemaType.AdditionalProperties = jsonschema.Bool(false)
+			} else {
+				jsonSchemaType.AdditionalProperties = jsonschema.Bool(true)
+			}
+			return jsonSchemaType
+		},
+	},
+	".google.protobuf.FieldMask": {
+		convert: func() *jsonschema.Type {
+			// A comma-separated list of lowerCamelCase paths (eg "user.displayName,photo"):
+			return &jsonschema.Type{
+				Type:    gojsonschema.TYPE_STRING,
+				Pattern: `^([a-z][a-zA-Z0-9]*(\.[a-z][a-zA-Z0-9]*)*(,[a-z][a-zA-Z0-9]*(\.[a-z][a-zA-Z0-9]*)*)*)?$`,
+			}
+		},
+	},
+	".google.protobuf.ListValue": {
+		convert: func() *jsonschema.Type {
+			return &jsonschema.Type{Type: gojsonschema.TYPE_ARRAY}
+		},
+	},
+	".google.protobuf.NullValue": {
+		nullable: true,
+		convert: func() *jsonschema.Type {
+			return &jsonschema.Type{Type: gojsonschema.TYPE_NULL}
+		},
+	},
+	".google.protobuf.Struct": {
+		convert: func() *jsonschema.Type {
+			return &jsonschema.Type{
+				Type:                 gojsonschema.TYPE_OBJECT,
+				AdditionalProperties: jsonschema.Bool(true),
+			}
+		},
+	},
+	".google.protobuf.Timestamp": {
+		convert: func() *jsonschema.Type {
+			return &jsonschema.Type{
+				Type:   gojsonschema.TYPE_STRING,
+				Format: "date-time",
+			}
+		},
+	},
+	".google.protobuf.Value": {
+		nullable: true,
+		convert: func() *jsonschema.Type {
+			// Any JSON value at all:
+			return &jsonschema.Type{}
+		},
+	},
+
+	// Wrappers are represented in JSON as the wrapped value, or NULL:
+	".google.protobuf.BoolValue": {
+		nullable: true,
+		convert: func() *jsonschema.Type {
+			return wrapperType(gojsonschema.TYPE_BOOLEAN)
+		},
+	},
+	".google.protobuf.BytesValue": {
+		nullable: true,
+		convert: func() *jsonschema.Type {
+			jsonSchemaType := wrapperType(gojsonschema.TYPE_STRING)
+			if base64Bytes {
+				applyBase64Encoding(valueOfWrapperType(jsonSchemaType))
+			}
+			return jsonSchemaType
+		},
+	},
+	".google.protobuf.DoubleValue": {
+		nullable: true,
+		convert: func() *jsonschema.Type {
+			return floatWrapperType(descriptor.FieldDescriptorProto_TYPE_DOUBLE)
+		},
+	},
+	".google.protobuf.FloatValue": {
+		nullable: true,
+		convert: func() *jsonschema.Type {
+			return floatWrapperType(descriptor.FieldDescriptorProto_TYPE_FLOAT)
+		},
+	},
+	".google.protobuf.Int32Value": {
+		nullable: true,
+		convert: func() *jsonschema.Type {
+			return integerWrapperType(descriptor.FieldDescriptorProto_TYPE_INT32, gojsonschema.TYPE_INTEGER)
+		},
+	},
+	".google.protobuf.Int64Value": {
+		nullable: true,
+		convert: func() *jsonschema.Type {
+			if disallowBigIntsAsStrings {
+				return integerWrapperType(descriptor.FieldDescriptorProto_TYPE_INT64, gojsonschema.TYPE_INTEGER)
+			}
+			return integerWrapperType(descriptor.FieldDescriptorProto_TYPE_INT64, gojsonschema.TYPE_INTEGER, gojsonschema.TYPE_STRING)
+		},
+	},
+	".google.protobuf.StringValue": {
+		nullable: true,
+		convert: func() *jsonschema.Type {
+			return wrapperType(gojsonschema.TYPE_STRING)
+		},
+	},
+	".google.protobuf.UInt32Value": {
+		nullable: true,
+		convert: func() *jsonschema.Type {
+			return integerWrapperType(descriptor.FieldDescriptorProto_TYPE_UINT32, gojsonschema.TYPE_INTEGER)
+		},
+	},
+	".google.protobuf.UInt64Value": {
+		nullable: true,
+		convert: func() *jsonschema.Type {
+			if disallowBigIntsAsStrings {
+				return integerWrapperType(descriptor.FieldDescriptorProto_TYPE_UINT64, gojsonschema.TYPE_INTEGER)
+			}
+			return integerWrapperType(descriptor.FieldDescriptorProto_TYPE_UINT64, gojsonschema.TYPE_INTEGER, gojsonschema.TYPE_STRING)
+		},
+	},
+}
+
+// Returns a JSON-Schema for a wrapper type (one of the given JSON types, or NULL):
+func wrapperType(jsonTypes ...string) *jsonschema.Type {
+	if disallowOneOf {
+		return &jsonschema.Type{Type: jsonTypes[0]}
+	}
+
+	jsonSchemaType := &jsonschema.Type{
+		OneOf: []*jsonschema.Type{
+			{Type: gojsonschema.TYPE_NULL},
+		},
+	}
+	for _, jsonType := range jsonTypes {
+		jsonSchemaType.OneOf = append(jsonSchemaType.OneOf, &jsonschema.Type{Type: jsonType})
+	}
+	return jsonSchemaType
+}
+
+// Returns a JSON-Schema for a wrapper type of one of the integer types (limited to its range):
+func integerWrapperType(fieldType descriptor.FieldDescriptorProto_Type, jsonTypes ...string) *jsonschema.Type {
+	jsonSchemaType := wrapperType(jsonTypes...)
+	applyIntegerRange(jsonSchemaType, fieldType)
+	return jsonSchemaType
+}
+
+// Returns a JSON-Schema for a wrapper type of a float or double:
+func floatWrapperType(fieldType descriptor.FieldDescriptorProto_Type) *jsonschema.Type {
+	floatJSONSchemaTypes := floatTypes(fieldType)
+	if disallowOneOf {
+		return floatJSONSchemaTypes[0]
+	}
+	return &jsonschema.Type{
+		OneOf: append([]*jsonschema.Type{{Type: gojsonschema.TYPE_NULL}}, floatJSONSchemaTypes...),
+	}
+}
+
+// Returns the (first) non-NULL value of a wrapper type:
+func valueOfWrapperType(jsonSchemaType *jsonschema.Type) *jsonschema.Type {
+	for _, oneOf := range jsonSchemaType.OneOf {
+		if oneOf.Type != gojsonschema.TYPE_NULL {
+			return oneOf
+		}
+	}
+	return jsonSchemaType
+}
+
+// Converts a field whose type is one of the well-known types (allowNull is whether the field itself can be NULL):
+func convertWellKnownTypeField(desc *descriptor.FieldDescriptorProto, msg *descriptor.DescriptorProto, wkt wellKnownType, allowNull bool) *jsonschema.Type {
+	// Helpers for this inverse logic shit
+	allowOneOf := !disallowOneOf
+
+	jsonSchemaType := wkt.convert()
+
+	// Arrays of well-known types (which are never NULL):
+	if desc.GetLabel() == descriptor.FieldDescriptorProto_LABEL_REPEATED {
+		return &jsonschema.Type{
+			Items: jsonSchemaType,
+			Type:  gojsonschema.TYPE_ARRAY,
+		}
+	}
+
+	// Map values can't be NULL either, not even for wrappers (only where NULL is a value of its own, like for Value):
+	if msg.GetOptions().GetMapEntry() {
+		return nonNullWrapperType(jsonSchemaType)
+	}
+
+	// Optionally allow NULL values (singular messages always have presence):
+	if allowNull && allowOneOf && !wkt.nullable {
+		jsonSchemaType = &jsonschema.Type{
+			OneOf: []*jsonschema.Type{
+				{Type: gojsonschema.TYPE_NULL},
+				jsonSchemaType,
+			},
+		}
+	}
+
+	return jsonSchemaType
+}
+
+// Returns a wrapper type without NULL (as just the wrapped value's JSON-Schema, if there's only one):
+func nonNullWrapperType(jsonSchemaType *jsonschema.Type) *jsonschema.Type {
+	var nonNullJSONSchemaTypes []*jsonschema.Type
+	for _, oneOf := range jsonSchemaType.OneOf {
+		if oneOf.Type != gojsonschema.TYPE_NULL {
+			nonNullJSONSchemaTypes = append(nonNullJSONSchemaTypes, oneOf)
+		}
+	}
+	switch {
+	case len(nonNullJSONSchemaTypes) == len(jsonSchemaType.OneOf):
+		return jsonSchemaType
+	case len(nonNullJSONSchemaTypes) == 1:
+		return nonNullJSONSchemaTypes[0]
+	}
+	nonNullJSONSchemaType := *jsonSchemaType
+	nonNullJSONSchemaType.OneOf = nonNullJSONSchemaTypes
+	return &nonNullJSONSchemaType
+}
@@ -0,0 +1,62 @@
+package main
+
+import (
+	"encoding/binary"
+	"fmt"
+
+	"github.com/golang/protobuf/proto"
+)
+
+// wireField is a field decoded from the protobuf wire format, for when there is no generated Go type to decode into.
+type wireField struct {
+	number   int32  // Field number
+	wireType int    // Wire type (proto.WireVarint, proto.WireBytes etc)
+	value    uint64 // Value of varint, fixed32 and fixed64 fields
+	bytes    []byte // Value of length-delimited fields (strings, bytes, messages and packed repeated fields)
+}
+
+// Decodes every field of an encoded message (in the order they appear):
+func decodeWireFields(data []byte) ([]wireField, error) {
+	var fields []wireField
+	for len(data) > 0 {
+		key, n := proto.DecodeVarint(data)
+		if n == 0 {
+			return nil, fmt.Errorf("invalid field key")
+		}
+		data = data[n:]
+
+		field := wireField{
+			number:   int32(key >> 3),
+			wireType: int(key & 7),
+		}
+		switch field.wireType {
+		case proto.WireVarint:
+			if field.value, n = proto.DecodeVarint(data); n == 0 {
+				return nil, fmt.Errorf("invalid varint for field %d", field.number)
+			}
+		case proto.WireFixed64:
+			if n = 8; len(data) < n {
+				return nil, fmt.Errorf("truncated fixed64 for field %d", field.number)
+			}
+			field.value = binary.LittleEndian.Uint64(data)
+		case proto.WireFixed32:
+			if n = 4; len(data) < n {
+				return nil, fmt.Errorf("truncated fixed32 for field %d", field.number)
+			}
+			field.value = uint64(binary.LittleEndian.Uint32(data))
+		case proto.WireBytes:
+			length, lengthSize := proto.DecodeVarint(data)
+			if lengthSize == 0 || uint64(len(data)-lengthSize) < length {
+				return nil, fmt.Errorf("truncated bytes for field %d", field.number)
+			}
+			n = lengthSize + int(length)
+			field.bytes = data[lengthSize:n]
+		default:
+			return nil, fmt.Errorf("unexpected wire type %d for field %d", field.wireType, field.number)
+		}
+		data = data[n:]
+
+		fields = append(fields, field)
+	}
+	return fields, nil
+}